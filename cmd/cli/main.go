@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tconfig "github.com/octoposprime/op-be-shared/tool/config"
+	pc_cli "github.com/octoposprime/op-be-user/pkg/presentation/controller/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var internalConfig tconfig.InternalConfig
+
+var userImportModes = map[string]pb_user.UserImportMode{
+	"all-or-nothing": pb_user.UserImportMode_UserImportModeALLORNOTHING,
+	"best-effort":    pb_user.UserImportMode_UserImportModeBESTEFFORT,
+}
+
+var userExportFormats = map[string]pb_user.UserExportFormat{
+	pc_cli.FormatCSV:    pb_user.UserExportFormat_UserExportFormatCSV,
+	pc_cli.FormatNDJSON: pb_user.UserExportFormat_UserExportFormatNDJSON,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	internalConfig.ReadConfig()
+
+	switch os.Args[1] {
+	case "import":
+		importUsers(os.Args[2:])
+	case "export":
+		exportUsers(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cli import --file <path> [--format csv|ndjson] [--mode all-or-nothing|best-effort] [--dry-run]")
+	fmt.Fprintln(os.Stderr, "       cli export [--file <path>] [--format csv|ndjson] [--columns <column,...>] [--status <status>] [--type <type>] [--tags <tag,...>] [--search <text>] [--include-deleted]")
+	os.Exit(2)
+}
+
+func importUsers(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("file", "", "path of the CSV or NDJSON file, - reads the standard input")
+	format := flags.String("format", pc_cli.FormatCSV, "format of the file: csv or ndjson")
+	mode := flags.String("mode", "all-or-nothing", "commit mode: all-or-nothing or best-effort")
+	dryRun := flags.Bool("dry-run", false, "only validate the rows and report the errors")
+	if err := flags.Parse(args); err != nil || *file == "" {
+		usage()
+	}
+	userImportMode, ok := userImportModes[*mode]
+	if !ok {
+		usage()
+	}
+
+	reader := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	conn, err := grpc.Dial(internalConfig.Grpc.UserHost+":"+internalConfig.Grpc.UserPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+
+	results, err := pc_cli.NewCli(conn).ImportUsers(context.Background(), reader, *format, userImportMode, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	pc_cli.WriteUserImportResults(os.Stdout, results)
+	if results.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func exportUsers(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	file := flags.String("file", "-", "path of the exported file, - writes the standard output")
+	format := flags.String("format", pc_cli.FormatCSV, "format of the file: csv or ndjson")
+	columns := flags.String("columns", "", "comma separated columns of the file, all the importable columns if it is empty")
+	userStatus := flags.Int("status", 0, "only the users with the given status")
+	userType := flags.Int("type", 0, "only the users with the given type")
+	tags := flags.String("tags", "", "only the users with the given comma separated tags")
+	searchText := flags.String("search", "", "only the users whose user name, email or tags contain the given text")
+	includeDeleted := flags.Bool("include-deleted", false, "export the soft-deleted users as well")
+	if err := flags.Parse(args); err != nil {
+		usage()
+	}
+	userExportFormat, ok := userExportFormats[*format]
+	if !ok {
+		usage()
+	}
+
+	filter := &pb_user.UserFilter{
+		SearchText:     searchText,
+		IncludeDeleted: includeDeleted,
+	}
+	if *userStatus != 0 {
+		status := pb_user.UserStatus(*userStatus)
+		filter.UserStatus = &status
+	}
+	if *userType != 0 {
+		typ := pb_user.UserType(*userType)
+		filter.UserType = &typ
+	}
+	if *tags != "" {
+		filter.Tags = strings.Split(*tags, ",")
+	}
+	request := &pb_user.UserExportRequest{
+		Filter: filter,
+		Format: userExportFormat,
+	}
+	if *columns != "" {
+		request.Columns = strings.Split(*columns, ",")
+	}
+
+	out := os.Stdout
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	writer := bufio.NewWriter(out)
+
+	conn, err := grpc.Dial(internalConfig.Grpc.UserHost+":"+internalConfig.Grpc.UserPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+
+	if err := pc_cli.NewCli(conn).ExportUsers(context.Background(), writer, request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := writer.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
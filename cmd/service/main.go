@@ -3,7 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/golobby/container/v3"
 
@@ -85,7 +89,7 @@ func main() {
 
 	//Infrastructure User EBus Adapter
 	err = cont.Singleton(func() ia_ebus.EBusAdapter {
-		return ia_ebus.NewEBusAdapter(redisClient)
+		return ia_ebus.NewEBusAdapter(newEBusBackend(redisClient))
 	})
 	if err != nil {
 		panic(err)
@@ -127,6 +131,13 @@ func main() {
 		panic(err)
 	}
 
+	var service *as.Service
+	err = cont.Resolve(&service)
+	if err != nil {
+		panic(err)
+	}
+	go shutdown(service)
+
 	wg := sync.WaitGroup{}
 	if !internalConfig.Local {
 		wg.Add(1)
@@ -137,3 +148,30 @@ func main() {
 	wg.Wait()
 
 }
+
+// shutdown waits for the termination signal and lets the event bus messages in progress finish before exiting.
+func shutdown(service *as.Service) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	fmt.Println("Stopping User Service...")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(tseed.GetServiceConfigInstance().EBus.ShutdownWait)*time.Second)
+	defer cancel()
+	if err := service.StopEventListen(ctx); err != nil {
+		fmt.Println(err)
+	}
+	os.Exit(0)
+}
+
+// newEBusBackend returns the event bus backend chosen in the config, the in-memory one is the default of the local mode.
+func newEBusBackend(redisClient *tredis.RedisClient) ia_ebus.Backend {
+	backend := tseed.GetServiceConfigInstance().EBus.Backend
+	if backend == "" && internalConfig.Local {
+		backend = "memory"
+	}
+	if backend == "memory" {
+		fmt.Println("Using the in-memory event bus")
+		return ia_ebus.NewMemoryBackend()
+	}
+	return ia_ebus.NewRedisBackend(redisClient)
+}
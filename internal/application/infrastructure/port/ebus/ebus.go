@@ -13,6 +13,31 @@ type EBusPort interface {
 	// SetLogger sets logging call-back function
 	SetLogger(LogFunc func(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error))
 
-	// Listen listens to the event bus and calls the given callBack function for each received user.
-	Listen(ctx context.Context, channelName string, callBack func(channelName string, user me.User))
+	// Listen listens to the event bus and calls the given callBack function for each received user command.
+	// A message is delivered again until the callBack returns no error.
+	// The context given to the callBack carries the actor and the correlation id of the message.
+	// The messages of the same user are delivered one by one in order.
+	Listen(ctx context.Context, channelName string, callBack func(ctx context.Context, channelName string, userCommand me.UserCommand) error)
+
+	// ServeUserQueries answers the user filter queries of the channel with the users returned by the given callBack function.
+	// The reply is sent to the reply channel given by the query and carries its correlation id, an expired query is not answered.
+	ServeUserQueries(ctx context.Context, channelName string, callBack func(ctx context.Context, userFilter me.UserFilter) (me.Users, error))
+
+	// Close stops delivering the messages to the callBacks and waits until the messages in progress are done.
+	Close(ctx context.Context) error
+
+	// Publish sends the given user event to the event bus.
+	Publish(ctx context.Context, userEvent me.UserEvent) error
+
+	// PublishUserCommandResult sends the given outcome of a user command to the reply channel of its command channel.
+	PublishUserCommandResult(ctx context.Context, userCommandResult me.UserCommandResult) error
+
+	// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter.
+	GetDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (me.DeadLetters, error)
+
+	// ReplayDeadLetter sends the given dead-lettered message back to its channel.
+	ReplayDeadLetter(ctx context.Context, deadLetter me.DeadLetter) error
+
+	// DeleteDeadLetters hard-deletes the given dead-lettered messages of the channel, or all of them if no id is given.
+	DeleteDeadLetters(ctx context.Context, channelName string, ids []string) (int64, error)
 }
@@ -2,10 +2,12 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 )
 
 // DbPort is a port for Hexagonal Architecture Pattern.
@@ -17,15 +19,92 @@ type DbPort interface {
 	// GetUsersByFilter returns the users that match the given filter.
 	GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error)
 
+	// StreamUsersByFilter calls the given function for each user that matches the given filter, the users are read from a database cursor.
+	StreamUsersByFilter(ctx context.Context, userFilter me.UserFilter, fn func(user me.User) error) error
+
+	// GetUsersForUpdate returns the users of the given ids and locks them until the end of the transaction, it must be called in a transaction.
+	GetUsersForUpdate(ctx context.Context, userIds []uuid.UUID) (me.Users, error)
+
 	// SaveUser insert a new user or update the existing one in the database.
 	SaveUser(ctx context.Context, user me.User) (me.User, error)
 
-	// DeleteUser soft-deletes the given user in the database.
+	// DeleteUser soft-deletes the given user in the database, the deleter is kept with the deletion time.
 	DeleteUser(ctx context.Context, user me.User) (me.User, error)
 
+	// RestoreUser clears the soft-deletion of the given user in the database and returns the restored user.
+	RestoreUser(ctx context.Context, user me.User) (me.User, error)
+
+	// EraseUser irreversibly anonymizes the given user in the database and returns the anonymized tombstone.
+	EraseUser(ctx context.Context, user me.User) (me.User, error)
+
 	// GetUserPasswordByUserId returns active password of the given user.
 	GetUserPasswordByUserId(ctx context.Context, userId uuid.UUID) (me.UserPassword, error)
 
 	// ChangePassword changes the poassword of the given user in the database.
 	ChangePassword(ctx context.Context, userPassword me.UserPassword) (me.UserPassword, error)
+
+	// SaveUserStatusHistory inserts the given user status transition into the database.
+	SaveUserStatusHistory(ctx context.Context, userStatusHistory me.UserStatusHistory) (me.UserStatusHistory, error)
+
+	// GetUserStatusHistoryByFilter returns the status transitions of the user that match the given filter.
+	GetUserStatusHistoryByFilter(ctx context.Context, userStatusHistoryFilter me.UserStatusHistoryFilter) (me.UserStatusHistories, error)
+
+	// SaveUserActivities writes the given login and activity times of the users to the database.
+	SaveUserActivities(ctx context.Context, userActivities []me.UserActivity) error
+
+	// GetSuspensionEndedUsers returns at most limit of the suspended users whose suspension is over at the given time, ordered by their ids after afterId.
+	GetSuspensionEndedUsers(ctx context.Context, now time.Time, afterId uuid.UUID, limit int) (me.Users, error)
+
+	// GetExpiredUsers returns at most limit of the users of the given statuses whose account is expired at the given time, ordered by their ids after afterId.
+	GetExpiredUsers(ctx context.Context, userStatuses []mo.UserStatus, now time.Time, afterId uuid.UUID, limit int) (me.Users, error)
+
+	// GetDormantUsers returns at most limit of the active users that have no activity since inactiveBefore, ordered by their ids after afterId.
+	// If warnedBefore is zero only the users that are not warned yet are returned, otherwise only the users warned before it.
+	GetDormantUsers(ctx context.Context, inactiveBefore time.Time, warnedBefore time.Time, afterId uuid.UUID, limit int) (me.Users, error)
+
+	// SaveUserDormancyWarning writes the dormancy warning time of the given user to the database.
+	SaveUserDormancyWarning(ctx context.Context, userId uuid.UUID, warnedAt time.Time) error
+
+	// SaveUserLoginHistory inserts the given authentication attempt into the database.
+	SaveUserLoginHistory(ctx context.Context, userLoginHistory me.UserLoginHistory) (me.UserLoginHistory, error)
+
+	// GetUserLoginHistoryByFilter returns the authentication attempts of the user that match the given filter.
+	GetUserLoginHistoryByFilter(ctx context.Context, userLoginHistoryFilter me.UserLoginHistoryFilter) (me.UserLoginHistories, error)
+
+	// RunInTransaction calls fn in a database transaction, the DbPort calls made with the context given to fn join the transaction.
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// SaveOutboxEvent inserts the given event into the outbox.
+	SaveOutboxEvent(ctx context.Context, outboxEvent me.OutboxEvent) error
+
+	// GetPendingOutboxEvents returns the undelivered events of the outbox that are due at the given time, oldest first.
+	// Only the oldest undelivered event of each user is returned.
+	GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]me.OutboxEvent, error)
+
+	// SaveOutboxEventDelivery writes the result of the delivery attempt of the given event to the outbox.
+	SaveOutboxEventDelivery(ctx context.Context, outboxEvent me.OutboxEvent) error
+
+	// SaveAuditEntry appends the given entry to the audit trail, chaining it to the last entry.
+	SaveAuditEntry(ctx context.Context, auditEntry me.AuditEntry) (me.AuditEntry, error)
+
+	// GetAuditTrailByFilter returns the audit trail entries that match the given filter, latest first.
+	GetAuditTrailByFilter(ctx context.Context, auditFilter me.AuditFilter) (me.AuditEntries, error)
+
+	// GetAuditEntriesAfter returns at most limit of the audit trail entries after the given position, in the order of the audit trail.
+	GetAuditEntriesAfter(ctx context.Context, afterSeq int64, limit int) ([]me.AuditEntry, error)
+
+	// SaveUserReadAccesses inserts the given reads of the personal data of the users into the database.
+	SaveUserReadAccesses(ctx context.Context, userReadAccesses []me.UserReadAccess) error
+
+	// GetUserReadAccessesByFilter returns the reads of the personal data of the user that match the given filter, latest first.
+	GetUserReadAccessesByFilter(ctx context.Context, userReadAccessFilter me.UserReadAccessFilter) (me.UserReadAccesses, error)
+
+	// CountExpiredRecords returns the number of the records of the given dataset that are older than the given time.
+	CountExpiredRecords(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time) (int64, error)
+
+	// DeleteExpiredRecords hard-deletes at most limit records of the given dataset that are older than the given time.
+	DeleteExpiredRecords(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time, limit int) (int64, error)
+
+	// GetDeletedUsersBefore returns at most limit soft-deleted users that are deleted before the given time and not erased yet.
+	GetDeletedUsersBefore(ctx context.Context, before time.Time, limit int) (me.Users, error)
 }
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
@@ -22,4 +23,44 @@ type RedisPort interface {
 
 	// ChangePassword changes the poassword of the given user in the redis.
 	ChangePassword(ctx context.Context, userPassword me.UserPassword) error
+
+	// IncrementRateLimit increments the counter of the given key and returns its value in the current window.
+	IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// SaveUserActivity buffers the latest activity time of the user in the redis.
+	SaveUserActivity(ctx context.Context, userActivity me.UserActivity) error
+
+	// GetUserActivitiesToFlush moves the buffered activities aside and returns them for writing to the database.
+	GetUserActivitiesToFlush(ctx context.Context) ([]me.UserActivity, error)
+
+	// DeleteFlushedUserActivities hard-deletes the activities that are returned by GetUserActivitiesToFlush.
+	DeleteFlushedUserActivities(ctx context.Context) error
+
+	// DeleteUserActivitiesByUserId hard-deletes the buffered activities of the given user in the redis.
+	DeleteUserActivitiesByUserId(ctx context.Context, userId uuid.UUID) error
+
+	// ReserveIdempotencyKey marks the given key as in progress for the ttl if it is not used yet.
+	// It returns the stored result and false if the key is already used.
+	ReserveIdempotencyKey(ctx context.Context, key string, requestHash string, ttl time.Duration) (me.IdempotentResult, bool, error)
+
+	// SaveIdempotentResult stores the outcome of the request of the given key for the ttl.
+	SaveIdempotentResult(ctx context.Context, idempotentResult me.IdempotentResult, ttl time.Duration) error
+
+	// DeleteIdempotencyKey hard-deletes the given key so that the request can be retried.
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+
+	// SaveUserReadAccess buffers the given read of the personal data of the users in the redis.
+	SaveUserReadAccess(ctx context.Context, userReadAccess me.UserReadAccess) error
+
+	// GetUserReadAccessesToFlush moves the buffered reads aside and returns them for writing to the database.
+	GetUserReadAccessesToFlush(ctx context.Context) ([]me.UserReadAccess, error)
+
+	// DeleteFlushedUserReadAccesses hard-deletes the reads that are returned by GetUserReadAccessesToFlush.
+	DeleteFlushedUserReadAccesses(ctx context.Context) error
+
+	// AcquireLock takes the given lock on behalf of the owner for the ttl, it returns false if the lock is held by another owner.
+	AcquireLock(ctx context.Context, key string, owner string, ttl time.Duration) (bool, error)
+
+	// ReleaseLock frees the given lock if it is still held by the owner.
+	ReleaseLock(ctx context.Context, key string, owner string) error
 }
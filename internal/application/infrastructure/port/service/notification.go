@@ -0,0 +1,14 @@
+package application
+
+import (
+	"context"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+)
+
+// NotificationServicePort is a port for Hexagonal Architecture Pattern.
+// It is used to communicate with the other servies.
+type NotificationServicePort interface {
+	// Notify sends the given notification to the notification micro service.
+	Notify(ctx context.Context, notificationData *pb.NotificationData) (*pb.NotificationResult, error)
+}
@@ -4,4 +4,5 @@ package application
 // It is used to communicate with the other servies.
 type ServicePort interface {
 	LoggingServicePort
+	NotificationServicePort
 }
@@ -31,7 +31,78 @@ func (a CommandAdapter) DeleteUser(ctx context.Context, user me.User) (me.User,
 	return a.Service.DeleteUser(ctx, user)
 }
 
+// RestoreUser sends the given soft-deleted user to the application layer for undoing the deletion.
+func (a CommandAdapter) RestoreUser(ctx context.Context, user me.User) (me.User, error) {
+	return a.Service.RestoreUser(ctx, user)
+}
+
+// EraseUser sends the given user to the application layer for irreversibly anonymizing its personal data.
+func (a CommandAdapter) EraseUser(ctx context.Context, user me.User) (me.User, error) {
+	return a.Service.EraseUser(ctx, user)
+}
+
+// BatchUpdateUserStatus sends the given batch to the application layer for moving the users to the given status.
+func (a CommandAdapter) BatchUpdateUserStatus(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.Service.BatchUpdateUserStatus(ctx, userBatch)
+}
+
+// BatchUpdateUserRole sends the given batch to the application layer for changing the role of the users.
+func (a CommandAdapter) BatchUpdateUserRole(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.Service.BatchUpdateUserRole(ctx, userBatch)
+}
+
+// BatchAddTags sends the given batch to the application layer for adding the given tags to the users.
+func (a CommandAdapter) BatchAddTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.Service.BatchAddTags(ctx, userBatch)
+}
+
+// BatchRemoveTags sends the given batch to the application layer for removing the given tags from the users.
+func (a CommandAdapter) BatchRemoveTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.Service.BatchRemoveTags(ctx, userBatch)
+}
+
+// BatchDeleteUsers sends the given batch to the application layer for soft-deleting the users.
+func (a CommandAdapter) BatchDeleteUsers(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.Service.BatchDeleteUsers(ctx, userBatch)
+}
+
 // ChangePassword sends the given user password to the application layer for changing user password.
 func (a CommandAdapter) ChangePassword(ctx context.Context, userPassword me.UserPassword) error {
 	return a.Service.ChangePassword(ctx, userPassword)
 }
+
+// RegisterUser sends the given self-registration request to the application layer for creating a new pending user.
+func (a CommandAdapter) RegisterUser(ctx context.Context, registration me.UserRegistration) (me.User, error) {
+	return a.Service.RegisterUser(ctx, registration)
+}
+
+// ImportUsers sends the given bulk import to the application layer for creating the users and reporting the outcome of each row.
+// The rows are read in chunks of at most limit rows by the given next function until it returns no row.
+func (a CommandAdapter) ImportUsers(ctx context.Context, userImport me.UserImport, next func(limit int) ([]me.UserImportRow, error)) (me.UserImportResults, error) {
+	return a.Service.ImportUsers(ctx, userImport, next)
+}
+
+// ChangeUserStatus sends the given status transition to the application layer for moving the user through its lifecycle.
+func (a CommandAdapter) ChangeUserStatus(ctx context.Context, userStatusChange me.UserStatusChange) (me.User, error) {
+	return a.Service.ChangeUserStatus(ctx, userStatusChange)
+}
+
+// RecordUserActivity sends the given login or activity of the user to the application layer for updating the activity times.
+func (a CommandAdapter) RecordUserActivity(ctx context.Context, userActivity me.UserActivity) error {
+	return a.Service.RecordUserActivity(ctx, userActivity)
+}
+
+// RecordUserLogin sends the given authentication attempt of the user to the application layer for the login history.
+func (a CommandAdapter) RecordUserLogin(ctx context.Context, userLoginHistory me.UserLoginHistory) (me.UserLoginHistory, error) {
+	return a.Service.RecordUserLogin(ctx, userLoginHistory)
+}
+
+// ReplayDeadLetters sends the given dead-lettered messages to the application layer for sending them back to their channel.
+func (a CommandAdapter) ReplayDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error) {
+	return a.Service.ReplayDeadLetters(ctx, deadLetterFilter)
+}
+
+// PurgeDeadLetters sends the given dead-lettered messages to the application layer for deleting them.
+func (a CommandAdapter) PurgeDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error) {
+	return a.Service.PurgeDeadLetters(ctx, deadLetterFilter)
+}
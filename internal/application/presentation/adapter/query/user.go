@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
 )
 
@@ -10,3 +12,43 @@ import (
 func (a QueryAdapter) GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
 	return a.Service.GetUsersByFilter(ctx, userFilter)
 }
+
+// ExportUsers calls the given function for each user that matches the given filter without loading all the users into memory.
+func (a QueryAdapter) ExportUsers(ctx context.Context, userFilter me.UserFilter, fn func(user me.User) error) error {
+	return a.Service.ExportUsers(ctx, userFilter, fn)
+}
+
+// GetUserStatusHistory returns the status transitions of the user that match the given filter.
+func (a QueryAdapter) GetUserStatusHistory(ctx context.Context, userStatusHistoryFilter me.UserStatusHistoryFilter) (me.UserStatusHistories, error) {
+	return a.Service.GetUserStatusHistory(ctx, userStatusHistoryFilter)
+}
+
+// GetLoginHistory returns the authentication attempts of the user that match the given filter.
+func (a QueryAdapter) GetLoginHistory(ctx context.Context, userLoginHistoryFilter me.UserLoginHistoryFilter) (me.UserLoginHistories, error) {
+	return a.Service.GetLoginHistory(ctx, userLoginHistoryFilter)
+}
+
+// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter.
+func (a QueryAdapter) GetDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (me.DeadLetters, error) {
+	return a.Service.GetDeadLetters(ctx, deadLetterFilter)
+}
+
+// GetAuditTrail returns the audit trail entries that match the given filter.
+func (a QueryAdapter) GetAuditTrail(ctx context.Context, auditFilter me.AuditFilter) (me.AuditEntries, error) {
+	return a.Service.GetAuditTrail(ctx, auditFilter)
+}
+
+// VerifyAuditTrail checks that no entry of the audit trail is changed or removed.
+func (a QueryAdapter) VerifyAuditTrail(ctx context.Context) (me.AuditTrailVerification, error) {
+	return a.Service.VerifyAuditTrail(ctx)
+}
+
+// GetUserReadAccesses returns the reads of the personal data of the user that match the given filter.
+func (a QueryAdapter) GetUserReadAccesses(ctx context.Context, userReadAccessFilter me.UserReadAccessFilter) (me.UserReadAccesses, error) {
+	return a.Service.GetUserReadAccesses(ctx, userReadAccessFilter)
+}
+
+// ExportUserData returns everything the service holds about the given user for a subject access request.
+func (a QueryAdapter) ExportUserData(ctx context.Context, userId uuid.UUID) (me.UserDataExport, error) {
+	return a.Service.ExportUserData(ctx, userId)
+}
@@ -24,6 +24,49 @@ type UserCommandPort interface {
 	// DeleteUser sends the given user to the application layer for deleting data.
 	DeleteUser(ctx context.Context, user me.User) (me.User, error)
 
+	// RestoreUser sends the given soft-deleted user to the application layer for undoing the deletion.
+	RestoreUser(ctx context.Context, user me.User) (me.User, error)
+
+	// EraseUser sends the given user to the application layer for irreversibly anonymizing its personal data.
+	EraseUser(ctx context.Context, user me.User) (me.User, error)
+
+	// BatchUpdateUserStatus sends the given batch to the application layer for moving the users to the given status.
+	BatchUpdateUserStatus(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error)
+
+	// BatchUpdateUserRole sends the given batch to the application layer for changing the role of the users.
+	BatchUpdateUserRole(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error)
+
+	// BatchAddTags sends the given batch to the application layer for adding the given tags to the users.
+	BatchAddTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error)
+
+	// BatchRemoveTags sends the given batch to the application layer for removing the given tags from the users.
+	BatchRemoveTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error)
+
+	// BatchDeleteUsers sends the given batch to the application layer for soft-deleting the users.
+	BatchDeleteUsers(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error)
+
 	// ChangePassword sends the given user password to the application layer for changing user password.
 	ChangePassword(ctx context.Context, userPassword me.UserPassword) error
+
+	// RegisterUser sends the given self-registration request to the application layer for creating a new pending user.
+	RegisterUser(ctx context.Context, registration me.UserRegistration) (me.User, error)
+
+	// ImportUsers sends the given bulk import to the application layer for creating the users and reporting the outcome of each row.
+	// The rows are read in chunks of at most limit rows by the given next function until it returns no row.
+	ImportUsers(ctx context.Context, userImport me.UserImport, next func(limit int) ([]me.UserImportRow, error)) (me.UserImportResults, error)
+
+	// ChangeUserStatus sends the given status transition to the application layer for moving the user through its lifecycle.
+	ChangeUserStatus(ctx context.Context, userStatusChange me.UserStatusChange) (me.User, error)
+
+	// RecordUserActivity sends the given login or activity of the user to the application layer for updating the activity times.
+	RecordUserActivity(ctx context.Context, userActivity me.UserActivity) error
+
+	// RecordUserLogin sends the given authentication attempt of the user to the application layer for the login history.
+	RecordUserLogin(ctx context.Context, userLoginHistory me.UserLoginHistory) (me.UserLoginHistory, error)
+
+	// ReplayDeadLetters sends the given dead-lettered messages to the application layer for sending them back to their channel.
+	ReplayDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error)
+
+	// PurgeDeadLetters sends the given dead-lettered messages to the application layer for deleting them.
+	PurgeDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error)
 }
@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
 )
 
@@ -11,4 +13,28 @@ import (
 type UserQueryPort interface {
 	// GetUsersByFilter returns the users that match the given filter.
 	GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error)
+
+	// ExportUsers calls the given function for each user that matches the given filter without loading all the users into memory.
+	ExportUsers(ctx context.Context, userFilter me.UserFilter, fn func(user me.User) error) error
+
+	// GetUserStatusHistory returns the status transitions of the user that match the given filter.
+	GetUserStatusHistory(ctx context.Context, userStatusHistoryFilter me.UserStatusHistoryFilter) (me.UserStatusHistories, error)
+
+	// GetLoginHistory returns the authentication attempts of the user that match the given filter.
+	GetLoginHistory(ctx context.Context, userLoginHistoryFilter me.UserLoginHistoryFilter) (me.UserLoginHistories, error)
+
+	// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter.
+	GetDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (me.DeadLetters, error)
+
+	// GetAuditTrail returns the audit trail entries that match the given filter.
+	GetAuditTrail(ctx context.Context, auditFilter me.AuditFilter) (me.AuditEntries, error)
+
+	// VerifyAuditTrail checks that no entry of the audit trail is changed or removed.
+	VerifyAuditTrail(ctx context.Context) (me.AuditTrailVerification, error)
+
+	// GetUserReadAccesses returns the reads of the personal data of the user that match the given filter.
+	GetUserReadAccesses(ctx context.Context, userReadAccessFilter me.UserReadAccessFilter) (me.UserReadAccesses, error)
+
+	// ExportUserData returns everything the service holds about the given user for a subject access request.
+	ExportUserData(ctx context.Context, userId uuid.UUID) (me.UserDataExport, error)
 }
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// RecordUserActivity buffers the given login or activity of the user to be written to the database by the flush job.
+func (a *Service) RecordUserActivity(ctx context.Context, userActivity me.UserActivity) error {
+	if userActivity.OccurredAt.IsZero() {
+		userActivity.OccurredAt = time.Now()
+	}
+	if err := userActivity.Validate(); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RecordUserActivity", userId, err.Error()))
+		return err
+	}
+	return a.RedisPort.SaveUserActivity(ctx, userActivity)
+}
+
+// This is the user activity flush job handler of the application layer.
+func (a *Service) ActivityFlushJob() *Service {
+	flushInterval := tconfig.GetServiceConfigInstance().Activity.FlushInterval
+	if flushInterval <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(flushInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.FlushUserActivities(context.Background())
+		}
+	}()
+	return a
+}
+
+// activityLockKey is the lock that keeps the user activity flush on one replica at a time.
+const activityLockKey = "ACTIVITY"
+
+// FlushUserActivities writes the buffered login and activity times of the users to the database in one batch.
+// It runs on one replica at a time so that a replica does not delete the batch another one is writing, the other replicas skip the flush while the lock is held.
+func (a *Service) FlushUserActivities(ctx context.Context) {
+	lockTtl := time.Duration(tconfig.GetServiceConfigInstance().Activity.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = 30 * time.Second
+	}
+	owner := uuid.New().String()
+	locked, err := a.RedisPort.AcquireLock(ctx, activityLockKey, owner, lockTtl)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "FlushUserActivities", uuid.UUID{}.String(), err.Error()))
+		return
+	}
+	if !locked {
+		return
+	}
+	defer a.RedisPort.ReleaseLock(ctx, activityLockKey, owner)
+
+	userActivities, err := a.RedisPort.GetUserActivitiesToFlush(ctx)
+	if err != nil {
+		return
+	}
+	if len(userActivities) == 0 {
+		return
+	}
+	if err := a.DbPort.SaveUserActivities(ctx, userActivities); err != nil {
+		// The batch stays in the redis and is retried by the next flush.
+		return
+	}
+	a.RedisPort.DeleteFlushedUserActivities(ctx)
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// auditRedacted is the value recorded in the audit trail in place of a secret.
+const auditRedacted = "***"
+
+// saveAuditEntry appends the given command to the audit trail on behalf of the actor in the context.
+// The user mutations call it in their transaction through saveUserEvent, so the entry is only kept if the mutation is committed.
+// RecordUserActivity and RecordUserLogin are not audited here, they are kept in the activity times and the login history of the user.
+func (a *Service) saveAuditEntry(ctx context.Context, auditAction mo.AuditAction, targetId uuid.UUID, diff interface{}) error {
+	diffData, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	actorId, _ := uuid.Parse(userId)
+	requestId, _ := ctx.Value(mo.QueryKeyCorrelationId).(string)
+	_, err = a.DbPort.SaveAuditEntry(ctx, *me.NewAuditEntry(uuid.UUID{}, actorId, auditAction, targetId, requestId, string(diffData)))
+	return err
+}
+
+// saveUserAuditEntry appends the given mutation of the user to the audit trail with the changed fields of the user.
+func (a *Service) saveUserAuditEntry(ctx context.Context, userEventType mo.UserEventType, before me.User, after me.User) error {
+	diff, err := me.NewUserDiff(before, after)
+	if err != nil {
+		return err
+	}
+	if userEventType == mo.UserEventTypePASSWORDCHANGED {
+		diff["password"] = me.AuditChange{Old: auditRedacted, New: auditRedacted}
+	}
+	if userEventType == mo.UserEventTypeERASED {
+		// The erasure is recorded by the changed fields only, the erased values are not kept.
+		for field, change := range diff {
+			diff[field] = me.AuditChange{Old: auditRedacted, New: change.New}
+		}
+	}
+	targetId := after.Id
+	if targetId == (uuid.UUID{}) {
+		targetId = before.Id
+	}
+	return a.saveAuditEntry(ctx, mo.UserEventAuditActions[userEventType], targetId, diff)
+}
+
+// GetAuditTrail returns the audit trail entries that match the given filter.
+func (a *Service) GetAuditTrail(ctx context.Context, auditFilter me.AuditFilter) (me.AuditEntries, error) {
+	auditEntries, err := a.DbPort.GetAuditTrailByFilter(ctx, auditFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetAuditTrail", userId, err.Error()))
+		return me.AuditEntries{}, err
+	}
+	return auditEntries, nil
+}
+
+// VerifyAuditTrail checks that no entry of the audit trail is changed or removed, reading the entries in the order of the audit trail in batches.
+// It returns the position of the first entry that does not verify against its own hashes and the previous entry.
+// The removal of the last entries can not be detected, since no later entry refers to them.
+func (a *Service) VerifyAuditTrail(ctx context.Context) (me.AuditTrailVerification, error) {
+	var previous *me.AuditEntry
+	var verifiedEntries int64
+	for {
+		auditEntries, err := a.DbPort.GetAuditEntriesAfter(ctx, verifiedEntries, userBatchChunkSize())
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "VerifyAuditTrail", userId, err.Error()))
+			return me.AuditTrailVerification{}, err
+		}
+		for i := range auditEntries {
+			auditEntry := auditEntries[i]
+			// The first entry has no previous one, it must still be the first of the audit trail.
+			if !auditEntry.Verify(previous) || (previous == nil && (auditEntry.Seq != 1 || auditEntry.PrevHash != "")) {
+				userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+				go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeWARNING, "VerifyAuditTrail", userId, fmt.Sprintf("audit trail is broken at %v", auditEntry.Seq)))
+				return *me.NewAuditTrailVerification(false, verifiedEntries, auditEntry.Seq), nil
+			}
+			previous = &auditEntry
+			verifiedEntries = auditEntry.Seq
+		}
+		if len(auditEntries) < userBatchChunkSize() {
+			return *me.NewAuditTrailVerification(true, verifiedEntries, 0), nil
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter.
+func (a *Service) GetDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (me.DeadLetters, error) {
+	if err := checkDeadLetterChannel(deadLetterFilter.ChannelName); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDeadLetters", userId, err.Error()))
+		return me.DeadLetters{}, err
+	}
+	return a.EBusPort.GetDeadLetters(ctx, deadLetterFilter)
+}
+
+// ReplayDeadLetters sends the given dead-lettered messages of the channel back to the channel and returns the number of them.
+func (a *Service) ReplayDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error) {
+	if len(deadLetterFilter.Ids) == 0 {
+		err := mo.ErrorDeadLetterNotFound
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReplayDeadLetters", userId, err.Error()))
+		return 0, err
+	}
+	if deadLetterFilter.ChannelName == mo.ChannelResetUserPassword {
+		// The password of a dead-lettered password reset is redacted, it can only be purged.
+		err := mo.ErrorDeadLetterChannelIsNotAllowed
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReplayDeadLetters", userId, err.Error()))
+		return 0, err
+	}
+	deadLetters, err := a.GetDeadLetters(ctx, deadLetterFilter)
+	if err != nil {
+		return 0, err
+	}
+	if len(deadLetters.DeadLetters) != len(deadLetterFilter.Ids) {
+		err := mo.ErrorDeadLetterNotFound
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReplayDeadLetters", userId, err.Error()))
+		return 0, err
+	}
+	var count int64
+	for _, deadLetter := range deadLetters.DeadLetters {
+		if err := a.EBusPort.ReplayDeadLetter(ctx, deadLetter); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := a.saveDeadLetterAuditEntry(ctx, mo.AuditActionDEADLETTERREPLAY, deadLetterFilter, count); err != nil {
+		return count, err
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, "ReplayDeadLetters", userId, fmt.Sprintf("%v: %v messages replayed", deadLetterFilter.ChannelName, count)))
+	return count, nil
+}
+
+// PurgeDeadLetters deletes the given dead-lettered messages of the channel, or all of them if no id is given, and returns the number of them.
+func (a *Service) PurgeDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (int64, error) {
+	if err := checkDeadLetterChannel(deadLetterFilter.ChannelName); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "PurgeDeadLetters", userId, err.Error()))
+		return 0, err
+	}
+	count, err := a.EBusPort.DeleteDeadLetters(ctx, deadLetterFilter.ChannelName, deadLetterFilter.Ids)
+	if err != nil {
+		return 0, err
+	}
+	if err := a.saveDeadLetterAuditEntry(ctx, mo.AuditActionDEADLETTERPURGE, deadLetterFilter, count); err != nil {
+		return count, err
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, "PurgeDeadLetters", userId, fmt.Sprintf("%v: %v messages purged", deadLetterFilter.ChannelName, count)))
+	return count, nil
+}
+
+// saveDeadLetterAuditEntry appends the given dead-letter command to the audit trail with the handled messages.
+func (a *Service) saveDeadLetterAuditEntry(ctx context.Context, auditAction mo.AuditAction, deadLetterFilter me.DeadLetterFilter, count int64) error {
+	err := a.saveAuditEntry(ctx, auditAction, uuid.UUID{}, map[string]interface{}{
+		"channel_name": deadLetterFilter.ChannelName,
+		"ids":          deadLetterFilter.Ids,
+		"count":        count,
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "saveDeadLetterAuditEntry", userId, err.Error()))
+	}
+	return err
+}
+
+// checkDeadLetterChannel checks that the given channel is one of the listened channels.
+func checkDeadLetterChannel(channelName string) error {
+	for _, listenedChannel := range listenedChannels {
+		if channelName == listenedChannel {
+			return nil
+		}
+	}
+	return mo.ErrorDeadLetterChannelIsNotValid
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	pb_notification "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// dormancyLockKey is the lock that keeps the dormancy job on one replica at a time.
+const dormancyLockKey = "DORMANCY"
+
+// This is the dormant account job handler of the application layer.
+func (a *Service) DormancyJob() *Service {
+	dormancyConfig := tconfig.GetServiceConfigInstance().Dormancy
+	if dormancyConfig.InactiveDays <= 0 || dormancyConfig.JobInterval <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(dormancyConfig.JobInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.WarnDormantUsers(context.Background(), time.Now())
+			a.DeactivateDormantUsers(context.Background(), time.Now())
+		}
+	}()
+	return a
+}
+
+// WarnDormantUsers notifies the active users that are inactive for the configured days and flags them as warned.
+// It runs on one replica at a time so that a user is warned once, the other replicas skip the run while the lock is held.
+func (a *Service) WarnDormantUsers(ctx context.Context, now time.Time) {
+	dormancyConfig := tconfig.GetServiceConfigInstance().Dormancy
+	if dormancyConfig.InactiveDays <= 0 {
+		return
+	}
+	inactiveBefore := now.AddDate(0, 0, -dormancyConfig.InactiveDays)
+	a.runDormancyJob(ctx, "WarnDormantUsers", inactiveBefore, time.Time{}, func(user me.User) {
+		message := fmt.Sprintf("Your account has not been used for %v days.", dormancyConfig.InactiveDays)
+		if dormancyConfig.DeactivateDays > dormancyConfig.InactiveDays {
+			message += fmt.Sprintf(" It will be deactivated after %v days of inactivity.", dormancyConfig.DeactivateDays)
+		}
+		_, err := a.Notify(ctx, me.NewNotificationData().GenerateNotificationData(pb_notification.NotificationType_NotificationTypeWARNING, user.Id.String(), user.Email, "Inactive account", message))
+		if err != nil {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "WarnDormantUsers", uuid.UUID{}.String(), fmt.Sprintf("%v: %v", user.Id, err.Error())))
+			return
+		}
+		if err := a.DbPort.SaveUserDormancyWarning(ctx, user.Id, now); err != nil {
+			return
+		}
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeWARNING, "WarnDormantUsers", uuid.UUID{}.String(), fmt.Sprintf("%v: inactive for %v days", user.Id, dormancyConfig.InactiveDays)))
+	})
+}
+
+// DeactivateDormantUsers deactivates the warned users that are inactive for the configured days.
+// A user is only deactivated if the warning was sent at least the difference of the two periods ago.
+// It runs on one replica at a time, the other replicas skip the run while the lock is held.
+func (a *Service) DeactivateDormantUsers(ctx context.Context, now time.Time) {
+	dormancyConfig := tconfig.GetServiceConfigInstance().Dormancy
+	if dormancyConfig.InactiveDays <= 0 || dormancyConfig.DeactivateDays <= dormancyConfig.InactiveDays {
+		return
+	}
+	inactiveBefore := now.AddDate(0, 0, -dormancyConfig.DeactivateDays)
+	warnedBefore := now.AddDate(0, 0, -(dormancyConfig.DeactivateDays - dormancyConfig.InactiveDays))
+	a.runDormancyJob(ctx, "DeactivateDormantUsers", inactiveBefore, warnedBefore, func(user me.User) {
+		a.applyLifecycleTransition(ctx, "DeactivateDormantUsers", user, mo.UserStatusDEACTIVATED, "dormant account")
+	})
+}
+
+// runDormancyJob calls the given function for each dormant user of the given periods, reading the users in batches of the configured size.
+// The job holds the dormancy lock while it runs and stops before the lock expires, so that another replica can not take it meanwhile.
+func (a *Service) runDormancyJob(ctx context.Context, operation string, inactiveBefore time.Time, warnedBefore time.Time, fn func(user me.User)) {
+	dormancyConfig := tconfig.GetServiceConfigInstance().Dormancy
+	lockTtl := time.Duration(dormancyConfig.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = 30 * time.Minute
+	}
+	a.runUserBatchJob(ctx, operation, dormancyLockKey, lockTtl, dormancyConfig.BatchSize, func(afterId uuid.UUID, limit int) (me.Users, error) {
+		return a.DbPort.GetDormantUsers(ctx, inactiveBefore, warnedBefore, afterId, limit)
+	}, fn)
+}
@@ -2,28 +2,104 @@ package application
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	smodel "github.com/octoposprime/op-be-shared/pkg/model"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 )
 
+// These are the event bus channels that the service listens to.
+var listenedChannels []string = []string{
+	smodel.ChannelCreateUser,
+	smodel.ChannelDeleteUser,
+	mo.ChannelUpdateUser,
+	mo.ChannelChangeUserStatus,
+	mo.ChannelChangeUserRole,
+	mo.ChannelResetUserPassword,
+}
+
 // This is the event listener handler of the application layer.
 func (a *Service) EventListen() *Service {
-	go a.Listen(context.Background(), smodel.ChannelCreateUser, a.EventListenerCallBack)
-	go a.Listen(context.Background(), smodel.ChannelDeleteUser, a.EventListenerCallBack)
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopEventListen = cancel
+	for _, channelName := range listenedChannels {
+		go a.Listen(ctx, channelName, a.EventListenerCallBack)
+	}
+	go a.ServeUserQueries(ctx, mo.ChannelGetUsers, a.GetUsersByFilter)
 	return a
 }
 
+// StopEventListen stops reading the event bus and waits until the messages in progress are done or the context is done.
+func (a *Service) StopEventListen(ctx context.Context) error {
+	if a.stopEventListen != nil {
+		a.stopEventListen()
+	}
+	return a.EBusPort.Close(ctx)
+}
+
 // This is a call-back function of the event listener handler of the application layer.
-func (a *Service) EventListenerCallBack(channelName string, user me.User) {
-	if channelName == smodel.ChannelCreateUser {
+// It returns an error only if the message should be delivered again, a message rejected by the domain rules is not retried.
+// The outcome of the command is published to the reply channel of the channel once it is final.
+func (a *Service) EventListenerCallBack(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+	var user me.User
+	var err error
+	switch channelName {
+	case smodel.ChannelCreateUser:
 		// Used a.CreateUser instead of this method for Redis Caching
 		//a.SaveUser(context.Background(), user)
-		a.CreateUser(context.Background(), user)
-	} else if channelName == smodel.ChannelDeleteUser {
-		a.DeleteUser(context.Background(), user)
-	} else {
+		user, err = a.CreateUser(ctx, userCommand.User)
+	case smodel.ChannelDeleteUser:
+		user, err = a.DeleteUser(ctx, userCommand.User)
+	case mo.ChannelUpdateUser:
+		user, err = a.UpdateUserBase(ctx, userCommand.User)
+	case mo.ChannelChangeUserStatus:
+		user, err = a.ChangeUserStatus(ctx, userCommand.UserStatusChange)
+	case mo.ChannelChangeUserRole:
+		user, err = a.UpdateUserRole(ctx, userCommand.User)
+	case mo.ChannelResetUserPassword:
+		err = a.ChangePassword(ctx, userCommand.UserPassword)
+	default:
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EventListenerCallBack", channelName, smodel.ErrorChannelNameNotValid.Error()))
+		return nil
+	}
+	if errors.Is(err, mo.ErrorUserRequestIsInProgress) {
+		// The same event is being handled by another consumer, it is retried until its outcome is known.
+		return err
+	}
+	if err != nil && !isDomainError(err) {
+		return err
+	}
+	a.replyUserCommand(ctx, channelName, userCommand, user, err)
+	return nil
+}
+
+// replyUserCommand publishes the outcome of the given user command, a failed publish is only logged since the command is already applied.
+func (a *Service) replyUserCommand(ctx context.Context, channelName string, userCommand me.UserCommand, user me.User, err error) {
+	userId := user.Id
+	if userId == (uuid.UUID{}) {
+		userId = userCommand.UserId()
+	}
+	errorText := ""
+	if err != nil {
+		errorText = err.Error()
+	}
+	userCommandResult := me.NewUserCommandResult(userCommand.Id, channelName, userId, err == nil, errorText, user, time.Now())
+	if err := a.PublishUserCommandResult(ctx, *userCommandResult); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EventListenerCallBack", userId, err.Error()))
+	}
+}
+
+// isDomainError returns true if the given error is one of the domain errors.
+func isDomainError(err error) bool {
+	for _, domainError := range mo.GetErrors() {
+		if errors.Is(err, domainError) {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// runIdempotent runs the given command once for the idempotency key of the context.
+// A repeated request with the same key returns the outcome of the first one instead of running the command again.
+// The key is scoped by the caller, and a repeated key with another request payload is rejected as conflicting.
+// The command is run as it is if the context has no idempotency key or the idempotency is disabled.
+func (a *Service) runIdempotent(ctx context.Context, operation string, request interface{}, command func(ctx context.Context) (me.User, error)) (me.User, error) {
+	idempotencyKey, _ := ctx.Value(mo.QueryKeyIdempotencyKey).(string)
+	ttl := time.Duration(tconfig.GetServiceConfigInstance().Idempotency.Ttl) * time.Second
+	if idempotencyKey == "" || ttl <= 0 {
+		return command(ctx)
+	}
+	actorId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	key := operation + ":" + actorId + ":" + idempotencyKey
+	requestHash, err := hashRequest(request)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, actorId, err.Error()))
+		return me.User{}, err
+	}
+	lockTtl := time.Duration(tconfig.GetServiceConfigInstance().Idempotency.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = ttl
+	}
+	storedResult, reserved, err := a.RedisPort.ReserveIdempotencyKey(ctx, key, requestHash, lockTtl)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, userId, err.Error()))
+		return me.User{}, err
+	}
+	if !reserved {
+		if storedResult.RequestHash != requestHash {
+			err := mo.ErrorUserRequestIsConflicting
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, actorId, err.Error()))
+			return me.User{}, err
+		}
+		if storedResult.InProgress {
+			err := mo.ErrorUserRequestIsInProgress
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, userId, err.Error()))
+			return me.User{}, err
+		}
+		if storedResult.Error != "" {
+			return me.User{}, errorFromText(storedResult.Error)
+		}
+		return storedResult.User, nil
+	}
+	user, err := command(ctx)
+	if err != nil && !isDomainError(err) {
+		// The infrastructure errors are not kept so that the request can be retried with the same key.
+		_ = a.RedisPort.DeleteIdempotencyKey(ctx, key)
+		return user, err
+	}
+	errorText := ""
+	if err != nil {
+		errorText = err.Error()
+	}
+	_ = a.RedisPort.SaveIdempotentResult(ctx, *me.NewIdempotentResult(key, requestHash, false, user, errorText), ttl)
+	return user, err
+}
+
+// hashRequest returns the fingerprint of the given request payload.
+func hashRequest(request interface{}) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// errorFromText returns the domain error that has the given text, a new error is returned for the unknown text.
+func errorFromText(errorText string) error {
+	for _, domainError := range mo.GetErrors() {
+		if domainError != nil && domainError.Error() == errorText {
+			return domainError
+		}
+	}
+	return errors.New(errorText)
+}
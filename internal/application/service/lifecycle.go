@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// These are the statuses that an expired user can be deactivated from.
+var expirableUserStatuses []mo.UserStatus = []mo.UserStatus{
+	mo.UserStatusPENDING,
+	mo.UserStatusACTIVE,
+	mo.UserStatusSUSPENDED,
+	mo.UserStatusLOCKED,
+	mo.UserStatusINACTIVE,
+}
+
+// This is the user lifecycle job handler of the application layer.
+func (a *Service) LifecycleJob() *Service {
+	jobInterval := tconfig.GetServiceConfigInstance().Lifecycle.JobInterval
+	if jobInterval <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(jobInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.ReactivateSuspendedUsers(context.Background(), time.Now())
+			a.DeactivateExpiredUsers(context.Background(), time.Now())
+		}
+	}()
+	return a
+}
+
+// lifecycleLockKey is the lock that keeps the user lifecycle job on one replica at a time.
+const lifecycleLockKey = "LIFECYCLE"
+
+// ReactivateSuspendedUsers activates the suspended users whose suspension is over at the given time.
+// It runs on one replica at a time, the other replicas skip the run while the lock is held.
+func (a *Service) ReactivateSuspendedUsers(ctx context.Context, now time.Time) {
+	a.runLifecycleJob(ctx, "ReactivateSuspendedUsers", func(afterId uuid.UUID, limit int) (me.Users, error) {
+		return a.DbPort.GetSuspensionEndedUsers(ctx, now, afterId, limit)
+	}, func(user me.User) {
+		if !user.IsSuspensionOver(now) {
+			return
+		}
+		a.applyLifecycleTransition(ctx, "ReactivateSuspendedUsers", user, mo.UserStatusACTIVE, "suspension ended")
+	})
+}
+
+// DeactivateExpiredUsers deactivates the users whose account is expired at the given time.
+// It runs on one replica at a time, the other replicas skip the run while the lock is held.
+func (a *Service) DeactivateExpiredUsers(ctx context.Context, now time.Time) {
+	a.runLifecycleJob(ctx, "DeactivateExpiredUsers", func(afterId uuid.UUID, limit int) (me.Users, error) {
+		return a.DbPort.GetExpiredUsers(ctx, expirableUserStatuses, now, afterId, limit)
+	}, func(user me.User) {
+		if !user.IsExpired(now) {
+			return
+		}
+		a.applyLifecycleTransition(ctx, "DeactivateExpiredUsers", user, mo.UserStatusDEACTIVATED, "account expired")
+	})
+}
+
+// runLifecycleJob calls the given function for each user read by the given function, in batches of the configured size.
+// The job holds the lifecycle lock while it runs and stops before the lock expires, so that another replica can not take it meanwhile.
+func (a *Service) runLifecycleJob(ctx context.Context, operation string, read func(afterId uuid.UUID, limit int) (me.Users, error), fn func(user me.User)) {
+	lifecycleConfig := tconfig.GetServiceConfigInstance().Lifecycle
+	lockTtl := time.Duration(lifecycleConfig.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = 30 * time.Minute
+	}
+	a.runUserBatchJob(ctx, operation, lifecycleLockKey, lockTtl, lifecycleConfig.BatchSize, read, fn)
+}
+
+// runUserBatchJob calls the given function for each user read by the given function, in batches of the given size ordered by the user ids.
+// The job holds the given lock while it runs and stops before the lock expires, the other replicas skip the job while the lock is held.
+func (a *Service) runUserBatchJob(ctx context.Context, operation string, lockKey string, lockTtl time.Duration, batchSize int, read func(afterId uuid.UUID, limit int) (me.Users, error), fn func(user me.User)) {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	owner := uuid.New().String()
+	locked, err := a.RedisPort.AcquireLock(ctx, lockKey, owner, lockTtl)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, uuid.UUID{}.String(), err.Error()))
+		return
+	}
+	if !locked {
+		return
+	}
+	defer a.RedisPort.ReleaseLock(ctx, lockKey, owner)
+	deadline := time.Now().Add(lockTtl)
+
+	var afterId uuid.UUID
+	for time.Now().Before(deadline) {
+		users, err := read(afterId, batchSize)
+		if err != nil {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, operation, uuid.UUID{}.String(), err.Error()))
+			return
+		}
+		for _, user := range users.Users {
+			if !time.Now().Before(deadline) {
+				return
+			}
+			fn(user)
+			afterId = user.Id
+		}
+		if len(users.Users) < batchSize {
+			return
+		}
+	}
+}
+
+// applyLifecycleTransition moves the given user to the given status on behalf of the system and logs the result.
+func (a *Service) applyLifecycleTransition(ctx context.Context, path string, user me.User, userStatus mo.UserStatus, reason string) {
+	_, err := a.ChangeUserStatus(ctx, *me.NewUserStatusChange(user.Id, userStatus, reason, time.Time{}))
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, path, uuid.UUID{}.String(), fmt.Sprintf("%v: %v", user.Id, err.Error())))
+		return
+	}
+	go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, path, uuid.UUID{}.String(), fmt.Sprintf("%v: %v -> %v, %v", user.Id, user.UserStatus, userStatus, reason)))
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// RecordUserLogin persists the given authentication attempt of the user.
+// A successful attempt also updates the last login time of the user.
+func (a *Service) RecordUserLogin(ctx context.Context, userLoginHistory me.UserLoginHistory) (me.UserLoginHistory, error) {
+	if err := userLoginHistory.Validate(); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RecordUserLogin", userId, err.Error()))
+		return me.UserLoginHistory{}, err
+	}
+	userLoginHistory, err := a.DbPort.SaveUserLoginHistory(ctx, userLoginHistory)
+	if err != nil {
+		return me.UserLoginHistory{}, err
+	}
+	if userLoginHistory.Success {
+		if err := a.RecordUserActivity(ctx, *me.NewUserActivity(userLoginHistory.UserId, mo.UserActivityTypeLOGIN, userLoginHistory.CreatedAt)); err != nil {
+			return userLoginHistory, err
+		}
+	}
+	return userLoginHistory, nil
+}
+
+// GetLoginHistory returns the authentication attempts of the user that match the given filter.
+func (a *Service) GetLoginHistory(ctx context.Context, userLoginHistoryFilter me.UserLoginHistoryFilter) (me.UserLoginHistories, error) {
+	if userLoginHistoryFilter.UserId.String() == "" || userLoginHistoryFilter.UserId == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetLoginHistory", userId, err.Error()))
+		return me.UserLoginHistories{}, err
+	}
+	return a.DbPort.GetUserLoginHistoryByFilter(ctx, userLoginHistoryFilter)
+}
@@ -0,0 +1,12 @@
+package application
+
+import (
+	"context"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+)
+
+// Notify sends the given notification to the notification micro service.
+func (a *Service) Notify(ctx context.Context, notificationData *pb.NotificationData) (*pb.NotificationResult, error) {
+	return a.ServicePort.Notify(ctx, notificationData)
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// outboxLockKey is the lock that keeps the outbox relay on one replica at a time.
+const outboxLockKey = "OUTBOX"
+
+// This is the outbox relay job handler of the application layer.
+func (a *Service) OutboxRelayJob() *Service {
+	relayInterval := tconfig.GetServiceConfigInstance().Outbox.RelayInterval
+	if relayInterval <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(relayInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.RelayOutboxEvents(context.Background(), time.Now())
+		}
+	}()
+	return a
+}
+
+// RelayOutboxEvents delivers the due events of the outbox to the event bus and marks them sent.
+// A failed event is retried with an exponential backoff, the later events of the same user are not read until it is delivered.
+// It runs on one replica at a time so that an event is not published by every replica, the other replicas skip the run while the lock is held.
+func (a *Service) RelayOutboxEvents(ctx context.Context, now time.Time) {
+	outboxConfig := tconfig.GetServiceConfigInstance().Outbox
+	lockTtl := time.Duration(outboxConfig.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = 30 * time.Second
+	}
+	owner := uuid.New().String()
+	locked, err := a.RedisPort.AcquireLock(ctx, outboxLockKey, owner, lockTtl)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RelayOutboxEvents", uuid.UUID{}.String(), err.Error()))
+		return
+	}
+	if !locked {
+		return
+	}
+	defer a.RedisPort.ReleaseLock(ctx, outboxLockKey, owner)
+	// The run stops before the lock expires, so that another replica can not publish the same events meanwhile.
+	deadline := time.Now().Add(lockTtl)
+
+	outboxEvents, err := a.DbPort.GetPendingOutboxEvents(ctx, now, outboxConfig.BatchSize)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RelayOutboxEvents", uuid.UUID{}.String(), err.Error()))
+		return
+	}
+	for _, outboxEvent := range outboxEvents {
+		if !time.Now().Before(deadline) {
+			return
+		}
+		if err := a.EBusPort.Publish(ctx, outboxEvent.UserEvent); err != nil {
+			outboxEvent.Attempts++
+			outboxEvent.NextAttemptAt = now.Add(outboxBackoff(outboxEvent.Attempts, outboxConfig.MaxBackoff))
+			outboxEvent.LastError = err.Error()
+		} else {
+			outboxEvent.SentAt = time.Now()
+		}
+		if err := a.DbPort.SaveOutboxEventDelivery(ctx, outboxEvent); err != nil {
+			// An event that is published but not marked sent is published again, the consumers drop the duplicate by its id.
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RelayOutboxEvents", uuid.UUID{}.String(), err.Error()))
+		}
+	}
+}
+
+// outboxBackoff returns the delay before the next delivery attempt, doubling from one second up to maxBackoff seconds.
+func outboxBackoff(attempts int, maxBackoff int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < time.Duration(maxBackoff)*time.Second; i++ {
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > time.Duration(maxBackoff)*time.Second {
+		backoff = time.Duration(maxBackoff) * time.Second
+	}
+	return backoff
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// recordUserReadAccess buffers the read of the returned users by the caller in the context, if the read audit is enabled.
+// It does not wait for the buffer, the reads are written to the database by the flush job.
+func (a *Service) recordUserReadAccess(ctx context.Context, operation string, filter interface{}, users []me.User) {
+	if !tconfig.GetServiceConfigInstance().ReadAudit.Enabled || len(users) == 0 {
+		return
+	}
+	filterData, _ := json.Marshal(filter)
+	userIds := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		userIds[i] = user.Id
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	actorId, _ := uuid.Parse(userId)
+	requestId, _ := ctx.Value(mo.QueryKeyCorrelationId).(string)
+	userReadAccess := *me.NewUserReadAccess(uuid.New(), actorId, operation, string(filterData), userIds, requestId, time.Now())
+	go a.RedisPort.SaveUserReadAccess(context.Background(), userReadAccess)
+}
+
+// GetUserReadAccesses returns the reads of the personal data of the user that match the given filter.
+func (a *Service) GetUserReadAccesses(ctx context.Context, userReadAccessFilter me.UserReadAccessFilter) (me.UserReadAccesses, error) {
+	if userReadAccessFilter.UserId.String() == "" || userReadAccessFilter.UserId == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccesses", userId, err.Error()))
+		return me.UserReadAccesses{}, err
+	}
+	return a.DbPort.GetUserReadAccessesByFilter(ctx, userReadAccessFilter)
+}
+
+// This is the read audit flush job handler of the application layer.
+func (a *Service) ReadAuditFlushJob() *Service {
+	readAuditConfig := tconfig.GetServiceConfigInstance().ReadAudit
+	if !readAuditConfig.Enabled || readAuditConfig.FlushInterval <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(readAuditConfig.FlushInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.FlushUserReadAccesses(context.Background())
+		}
+	}()
+	return a
+}
+
+// readAuditLockKey is the lock that keeps the read audit flush on one replica at a time.
+const readAuditLockKey = "READ_AUDIT"
+
+// FlushUserReadAccesses writes the buffered reads of the personal data of the users to the database in one batch.
+// It runs on one replica at a time so that a replica does not delete the batch another one is writing, the other replicas skip the flush while the lock is held.
+func (a *Service) FlushUserReadAccesses(ctx context.Context) {
+	lockTtl := time.Duration(tconfig.GetServiceConfigInstance().ReadAudit.LockTtl) * time.Second
+	if lockTtl <= 0 {
+		lockTtl = 30 * time.Second
+	}
+	owner := uuid.New().String()
+	locked, err := a.RedisPort.AcquireLock(ctx, readAuditLockKey, owner, lockTtl)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "FlushUserReadAccesses", uuid.UUID{}.String(), err.Error()))
+		return
+	}
+	if !locked {
+		return
+	}
+	defer a.RedisPort.ReleaseLock(ctx, readAuditLockKey, owner)
+
+	userReadAccesses, err := a.RedisPort.GetUserReadAccessesToFlush(ctx)
+	if err != nil {
+		return
+	}
+	if len(userReadAccesses) == 0 {
+		return
+	}
+	if err := a.DbPort.SaveUserReadAccesses(ctx, userReadAccesses); err != nil {
+		// The batch stays in the redis and is retried by the next flush.
+		return
+	}
+	a.RedisPort.DeleteFlushedUserReadAccesses(ctx)
+}
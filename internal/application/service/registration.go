@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// RegisterUser creates a pending user from the given public self-registration request.
+func (a *Service) RegisterUser(ctx context.Context, registration me.UserRegistration) (me.User, error) {
+	registrationConfig := tconfig.GetServiceConfigInstance().Registration
+	if !registrationConfig.Enabled {
+		err := mo.ErrorUserRegistrationIsDisabled
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if err := registration.Validate(); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if registrationConfig.RateLimitPerIp > 0 && registration.ClientIp != "" {
+		count, err := a.RedisPort.IncrementRateLimit(ctx, "REGISTRATION:"+registration.ClientIp, time.Duration(registrationConfig.RateLimitWindow)*time.Second)
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+			return me.User{}, err
+		}
+		if count > registrationConfig.RateLimitPerIp {
+			err := mo.ErrorUserRegistrationRateLimitExceeded
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", registration.ClientIp, err.Error()))
+			return me.User{}, err
+		}
+	}
+
+	// The caller can not choose the privileges of the self-registered user.
+	user := registration.User
+	user.Role = registrationConfig.DefaultRole
+	user.UserType = mo.UserType(registrationConfig.DefaultUserType)
+	user.UserStatus = mo.UserStatusPENDING
+	user.Tags = []string{}
+	if err := a.CheckEmailRules(&user); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if err := a.CheckEmailDomainRules(&user, registrationConfig.AllowedEmailDomains, registrationConfig.DeniedEmailDomains, registrationConfig.DisposableDomains); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	userPassword := me.NewEmptyUserPassword()
+	userPassword.Password = registration.Password
+	if err := a.CheckPasswordRules(userPassword); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+
+	// The password is not a part of the request fingerprint so that nothing derived from it is kept in the cache.
+	return a.runIdempotent(ctx, "RegisterUser", user, func(ctx context.Context) (me.User, error) {
+		return a.registerUser(ctx, user, *userPassword)
+	})
+}
+
+// registerUser saves the given user with its password in one transaction, so that a failure does not leave a user without a password.
+func (a *Service) registerUser(ctx context.Context, user me.User, userPassword me.UserPassword) (me.User, error) {
+	user.Id = uuid.UUID{}
+	if err := a.checkNewUser(ctx, &user); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	hashedPassword, err := hashPassword(userPassword.Password)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	userPassword.Password = hashedPassword
+	userPassword.PasswordStatus = mo.PasswordStatusACTIVE
+	err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = a.saveNewUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		userPassword.UserId = user.Id
+		userPassword, err = a.saveUserPassword(ctx, user, userPassword)
+		return err
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	// The password is cached only after it is committed, it is read from the db if the cache fails.
+	if err := a.RedisPort.ChangePassword(ctx, userPassword); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RegisterUser", userId, err.Error()))
+	}
+	return user, nil
+}
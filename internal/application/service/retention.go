@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// retentionLockKey is the lock that keeps the retention job on one replica at a time.
+const retentionLockKey = "RETENTION"
+
+// This is the retention job handler of the application layer.
+func (a *Service) RetentionJob() *Service {
+	retentionConfig := tconfig.GetServiceConfigInstance().Retention
+	if retentionConfig.JobInterval <= 0 || retentionConfig.BatchSize <= 0 {
+		return a
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(retentionConfig.JobInterval) * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.PurgeExpiredData(context.Background(), time.Now())
+		}
+	}()
+	return a
+}
+
+// PurgeExpiredData purges the records of the datasets that are older than their retention periods at the given time and returns what is purged.
+// The records are only counted on a dry run. The soft-deleted users are erased, so that the audit trail still resolves them.
+// It runs on one replica at a time, the other replicas skip the run while the lock is held.
+func (a *Service) PurgeExpiredData(ctx context.Context, now time.Time) []me.RetentionPurge {
+	retentionConfig := tconfig.GetServiceConfigInstance().Retention
+	lockTtl := time.Duration(retentionConfig.LockTtl) * time.Second
+	owner := uuid.New().String()
+	locked, err := a.RedisPort.AcquireLock(ctx, retentionLockKey, owner, lockTtl)
+	if err != nil || !locked {
+		return nil
+	}
+	defer a.RedisPort.ReleaseLock(ctx, retentionLockKey, owner)
+	// The run stops before the lock expires, so that another replica can not take it meanwhile.
+	deadline := time.Now().Add(lockTtl)
+
+	retentionPeriods := []struct {
+		retentionDataset mo.RetentionDataset
+		period           time.Duration
+	}{
+		{mo.RetentionDatasetDELETEDUSER, time.Duration(retentionConfig.DeletedUserDays) * 24 * time.Hour},
+		{mo.RetentionDatasetINACTIVEPASSWORD, time.Duration(retentionConfig.InactivePasswordDays) * 24 * time.Hour},
+		{mo.RetentionDatasetLOGINHISTORY, time.Duration(retentionConfig.LoginHistoryDays) * 24 * time.Hour},
+		{mo.RetentionDatasetSENTOUTBOXEVENT, time.Duration(retentionConfig.SentOutboxEventHours) * time.Hour},
+	}
+	retentionPurges := []me.RetentionPurge{}
+	for _, retentionPeriod := range retentionPeriods {
+		if retentionPeriod.period <= 0 {
+			continue
+		}
+		retentionPurge := *me.NewRetentionPurge(retentionPeriod.retentionDataset, now.Add(-retentionPeriod.period), 0, retentionConfig.DryRun)
+		var err error
+		switch {
+		case retentionPurge.DryRun:
+			retentionPurge.Count, err = a.DbPort.CountExpiredRecords(ctx, retentionPurge.RetentionDataset, retentionPurge.Before)
+		case retentionPurge.RetentionDataset == mo.RetentionDatasetDELETEDUSER:
+			retentionPurge.Count, err = a.eraseExpiredUsers(ctx, retentionPurge.Before, retentionConfig.BatchSize, deadline)
+		default:
+			retentionPurge.Count, err = a.deleteExpiredRecords(ctx, retentionPurge.RetentionDataset, retentionPurge.Before, retentionConfig.BatchSize, deadline)
+		}
+		if err != nil {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "PurgeExpiredData", uuid.UUID{}.String(), err.Error()))
+		}
+		if retentionPurge.DryRun || retentionPurge.Count > 0 {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, "PurgeExpiredData", uuid.UUID{}.String(), retentionPurge.String()))
+		}
+		retentionPurges = append(retentionPurges, retentionPurge)
+	}
+	return retentionPurges
+}
+
+// deleteExpiredRecords hard-deletes the records of the dataset that are older than the given time in batches until the deadline.
+// It returns the number of the deleted records.
+func (a *Service) deleteExpiredRecords(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time, batchSize int, deadline time.Time) (int64, error) {
+	var count int64
+	for time.Now().Before(deadline) {
+		deleted, err := a.DbPort.DeleteExpiredRecords(ctx, retentionDataset, before, batchSize)
+		count += deleted
+		if err != nil || deleted < int64(batchSize) {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// eraseExpiredUsers erases the soft-deleted users that are deleted before the given time in batches until the deadline.
+// It returns the number of the erased users, a batch without any erased user ends the run.
+func (a *Service) eraseExpiredUsers(ctx context.Context, before time.Time, batchSize int, deadline time.Time) (int64, error) {
+	var count int64
+	for time.Now().Before(deadline) {
+		users, err := a.DbPort.GetDeletedUsersBefore(ctx, before, batchSize)
+		if err != nil {
+			return count, err
+		}
+		var erased int64
+		for _, user := range users.Users {
+			if erasedUser, _ := a.EraseUser(ctx, user); !erasedUser.ErasedAt.IsZero() {
+				erased++
+			}
+		}
+		count += erased
+		if erased == 0 || len(users.Users) < batchSize {
+			return count, nil
+		}
+	}
+	return count, nil
+}
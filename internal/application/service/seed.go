@@ -13,7 +13,7 @@ func (a Service) Migrate() Service {
 	for _, seedUser := range tseed.GetSeedConfigInstance().Users {
 		var seedUserFilter me.UserFilter
 		seedUserFilter.UserName = seedUser.User.UserName
-		inUsers, err := a.GetUsersByFilter(context.TODO(), seedUserFilter)
+		inUsers, err := a.DbPort.GetUsersByFilter(context.TODO(), seedUserFilter)
 		if err != nil {
 			panic(err)
 		}
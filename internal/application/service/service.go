@@ -1,6 +1,8 @@
 package application
 
 import (
+	"context"
+
 	ip_ebus "github.com/octoposprime/op-be-user/internal/application/infrastructure/port/ebus"
 	ip_repo "github.com/octoposprime/op-be-user/internal/application/infrastructure/port/repository"
 	ip_service "github.com/octoposprime/op-be-user/internal/application/infrastructure/port/service"
@@ -15,20 +17,28 @@ type Service struct {
 	ip_repo.RedisPort
 	ip_ebus.EBusPort
 	ip_service.ServicePort
+
+	stopEventListen context.CancelFunc // stopEventListen stops the event listeners started by EventListen.
 }
 
 // NewService creates a new *Service.
 func NewService(domainService *ds.Service, dbRepository ip_repo.DbPort, redisRepository ip_repo.RedisPort, eBus ip_ebus.EBusPort, internalService ip_service.ServicePort) *Service {
 	service := &Service{
-		domainService,
-		dbRepository,
-		redisRepository,
-		eBus,
-		internalService,
+		Service:     domainService,
+		DbPort:      dbRepository,
+		RedisPort:   redisRepository,
+		EBusPort:    eBus,
+		ServicePort: internalService,
 	}
 	service.DbPort.SetLogger(service.Log)
 	service.EBusPort.SetLogger(service.Log)
 	service.EventListen()
+	service.LifecycleJob()
+	service.ActivityFlushJob()
+	service.ReadAuditFlushJob()
+	service.DormancyJob()
+	service.OutboxRelayJob()
+	service.RetentionJob()
 	service.Migrate()
 	return service
 }
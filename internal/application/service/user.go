@@ -11,61 +11,90 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-// GetUsersByFilter returns the users that match the given filter.
+// GetUsersByFilter returns the users that match the given filter, the read is recorded in the read audit.
+// The commands of the service read the users through the DbPort, they are not recorded.
 func (a *Service) GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
-	return a.DbPort.GetUsersByFilter(ctx, userFilter)
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
+	if err != nil {
+		return me.Users{}, err
+	}
+	a.recordUserReadAccess(ctx, "GetUsersByFilter", userFilter, users.Users)
+	return users, nil
 }
 
 // CreateUser sends the given user to the repository of the infrastructure layer for creating a new user.
+// A request with an idempotency key that is already used returns the outcome of the first request.
 func (a *Service) CreateUser(ctx context.Context, user me.User) (me.User, error) {
+	return a.runIdempotent(ctx, "CreateUser", user, func(ctx context.Context) (me.User, error) {
+		return a.createUser(ctx, user)
+	})
+}
+
+// createUser validates and saves the given user as a new user.
+func (a *Service) createUser(ctx context.Context, user me.User) (me.User, error) {
 	user.Id = uuid.UUID{}
-	if err := a.ValidateUser(&user); err != nil {
+	if err := a.checkNewUser(ctx, &user); err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
 		return me.User{}, err
 	}
-	if err := a.CheckUserNameRules(&user); err != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
+	err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = a.saveNewUser(ctx, user)
+		return err
+	})
+	if err != nil {
 		return me.User{}, err
 	}
-	if err := a.CheckEmailRules(&user); err != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
-		return me.User{}, err
+	return user, nil
+}
+
+// checkNewUser checks the given user against the rules and the existing users before it is created.
+// The status of the user is set to active if it is not given.
+func (a *Service) checkNewUser(ctx context.Context, user *me.User) error {
+	if err := a.ValidateUser(user); err != nil {
+		return err
+	}
+	if err := a.CheckUserNameRules(user); err != nil {
+		return err
+	}
+	if err := a.CheckEmailRules(user); err != nil {
+		return err
 	}
 	var userEmailCheckFilter me.UserFilter
 	userEmailCheckFilter.Email = user.Email
-	emailExistsUsers, err := a.GetUsersByFilter(ctx, userEmailCheckFilter)
+	emailExistsUsers, err := a.DbPort.GetUsersByFilter(ctx, userEmailCheckFilter)
 	if err != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
-		return me.User{}, err
+		return err
 	}
 	if emailExistsUsers.TotalRows > 0 {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		err := mo.ErrorUserEmailIsExists
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
-		return me.User{}, err
+		return mo.ErrorUserEmailIsExists
 	}
 	var userNameCheckFilter me.UserFilter
 	userNameCheckFilter.UserName = user.UserName
-	nameExistsUsers, err := a.GetUsersByFilter(ctx, userNameCheckFilter)
+	nameExistsUsers, err := a.DbPort.GetUsersByFilter(ctx, userNameCheckFilter)
 	if err != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
-		return me.User{}, err
+		return err
 	}
 	if nameExistsUsers.TotalRows > 0 {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		err := mo.ErrorUserUsernameIsExists
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CreateUser", userId, err.Error()))
-		return me.User{}, err
+		return mo.ErrorUserUsernameIsExists
 	}
 	if user.UserStatus == mo.UserStatusNONE {
 		user.UserStatus = mo.UserStatusACTIVE
 	}
-	return a.DbPort.SaveUser(ctx, user)
+	return a.CheckUserStatusTransition(mo.UserStatusNONE, user.UserStatus, "")
+}
+
+// saveNewUser saves the given checked user as a new user, it must be called in a transaction.
+func (a *Service) saveNewUser(ctx context.Context, user me.User) (me.User, error) {
+	user, err := a.DbPort.SaveUser(ctx, user)
+	if err != nil {
+		return me.User{}, err
+	}
+	if err := a.recordUserStatusTransition(ctx, user.Id, mo.UserStatusNONE, user.UserStatus, ""); err != nil {
+		return me.User{}, err
+	}
+	return user, a.saveUserEvent(ctx, mo.UserEventTypeCREATED, me.User{}, user)
 }
 
 // UpdateUserBase sends the given base values of the user to the repository of the infrastructure layer for updating base values of user data.
@@ -78,7 +107,7 @@ func (a *Service) UpdateUserBase(ctx context.Context, user me.User) (me.User, er
 	}
 	var userFilter me.UserFilter
 	userFilter.Id = user.Id
-	users, err := a.GetUsersByFilter(ctx, userFilter)
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserStatus", userId, err.Error()))
@@ -89,81 +118,103 @@ func (a *Service) UpdateUserBase(ctx context.Context, user me.User) (me.User, er
 		dbUser.Tags = user.Tags
 		dbUser.FirstName = user.FirstName
 		dbUser.LastName = user.LastName
+		dbUser.ExpiresAt = user.ExpiresAt
 		if err := a.ValidateUser(&dbUser); err != nil {
 			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserStatus", userId, err.Error()))
 			return me.User{}, err
 		}
-		return a.DbPort.SaveUser(ctx, dbUser)
+		err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			dbUser, err = a.saveUpdatedUser(ctx, mo.UserEventTypeUPDATED, users.Users[0], dbUser)
+			return err
+		})
+		if err != nil {
+			return me.User{}, err
+		}
+		return dbUser, nil
 	} else {
 		return user, mo.ErrorUserNotFound
 	}
 }
 
 // UpdateUserStatus sends the given status value of the user to the repository of the infrastructure layer for updating status of user data.
+// The transition is guarded by the user lifecycle, the legacy clients send no reason so only the transitions that do not require one are allowed.
+// They are recorded with the default reason.
 func (a *Service) UpdateUserStatus(ctx context.Context, user me.User) (me.User, error) {
+	return a.changeUserStatus(ctx, *me.NewUserStatusChange(user.Id, user.UserStatus, "", user.SuspendedUntil), mo.UserStatusUpdateReason)
+}
+
+// UpdateUserRole sends the given type value of the user to the repository of the infrastructure layer for updating role of user data.
+func (a *Service) UpdateUserRole(ctx context.Context, user me.User) (me.User, error) {
 	if user.Id.String() == "" || user.Id == (uuid.UUID{}) {
 		err := mo.ErrorUserIdIsEmpty
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserStatus", userId, err.Error()))
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
 		return me.User{}, err
 	}
 	var userFilter me.UserFilter
 	userFilter.Id = user.Id
-	users, err := a.GetUsersByFilter(ctx, userFilter)
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserStatus", userId, err.Error()))
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
 		return me.User{}, err
 	}
 	if users.TotalRows > 0 {
 		dbUser := users.Users[0]
-		dbUser.UserStatus = user.UserStatus
+		dbUser.Role = user.Role
 		if err := a.ValidateUser(&dbUser); err != nil {
 			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserStatus", userId, err.Error()))
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
 			return me.User{}, err
 		}
-		return a.DbPort.SaveUser(ctx, dbUser)
+		err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			dbUser, err = a.saveUpdatedUser(ctx, mo.UserEventTypeROLECHANGED, users.Users[0], dbUser)
+			return err
+		})
+		if err != nil {
+			return me.User{}, err
+		}
+		return dbUser, nil
 	} else {
 		return user, mo.ErrorUserNotFound
 	}
 }
 
-// UpdateUserRole sends the given type value of the user to the repository of the infrastructure layer for updating role of user data.
-func (a *Service) UpdateUserRole(ctx context.Context, user me.User) (me.User, error) {
+// DeleteUser sends the given user to the repository of the infrastructure layer for deleting data.
+func (a *Service) DeleteUser(ctx context.Context, user me.User) (me.User, error) {
 	if user.Id.String() == "" || user.Id == (uuid.UUID{}) {
 		err := mo.ErrorUserIdIsEmpty
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUser", userId, err.Error()))
 		return me.User{}, err
 	}
 	var userFilter me.UserFilter
 	userFilter.Id = user.Id
-	users, err := a.GetUsersByFilter(ctx, userFilter)
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUser", userId, err.Error()))
 		return me.User{}, err
 	}
-	if users.TotalRows > 0 {
-		dbUser := users.Users[0]
-		dbUser.Role = user.Role
-		if err := a.ValidateUser(&dbUser); err != nil {
-			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "UpdateUserRole", userId, err.Error()))
-			return me.User{}, err
-		}
-		return a.DbPort.SaveUser(ctx, dbUser)
-	} else {
-		return user, mo.ErrorUserNotFound
+	if users.TotalRows == 0 {
+		return me.User{}, mo.ErrorUserNotFound
 	}
-}
-
-// DeleteUser sends the given user to the repository of the infrastructure layer for deleting data.
-func (a *Service) DeleteUser(ctx context.Context, user me.User) (me.User, error) {
-	var err error
-	user, err = a.DbPort.DeleteUser(ctx, user)
+	dbUser := users.Users[0]
+	fromStatus := dbUser.UserStatus
+	if err := a.CheckUserStatusTransition(fromStatus, mo.UserStatusDELETED, ""); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	dbUser.UserStatus = mo.UserStatusDELETED
+	err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = a.saveDeletedUser(ctx, users.Users[0], dbUser)
+		return err
+	})
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUser", userId, err.Error()))
@@ -179,6 +230,203 @@ func (a *Service) DeleteUser(ctx context.Context, user me.User) (me.User, error)
 	return user, err
 }
 
+// saveUpdatedUser saves the given changed user with the event of the change, it must be called in a transaction.
+func (a *Service) saveUpdatedUser(ctx context.Context, userEventType mo.UserEventType, before me.User, user me.User) (me.User, error) {
+	user, err := a.DbPort.SaveUser(ctx, user)
+	if err != nil {
+		return me.User{}, err
+	}
+	return user, a.saveUserEvent(ctx, userEventType, before, user)
+}
+
+// saveDeletedUser saves and soft-deletes the given user with its status transition and event, it must be called in a transaction.
+func (a *Service) saveDeletedUser(ctx context.Context, before me.User, user me.User) (me.User, error) {
+	user, err := a.DbPort.SaveUser(ctx, user)
+	if err != nil {
+		return me.User{}, err
+	}
+	user, err = a.DbPort.DeleteUser(ctx, user)
+	if err != nil {
+		return me.User{}, err
+	}
+	if err := a.recordUserStatusTransition(ctx, user.Id, before.UserStatus, mo.UserStatusDELETED, ""); err != nil {
+		return me.User{}, err
+	}
+	return user, a.saveUserEvent(ctx, mo.UserEventTypeDELETED, before, user)
+}
+
+// RestoreUser sends the given soft-deleted user to the repository of the infrastructure layer for undoing the deletion.
+// The user gets back the status it had before the deletion and its active password.
+func (a *Service) RestoreUser(ctx context.Context, user me.User) (me.User, error) {
+	if user.Id.String() == "" || user.Id == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var userFilter me.UserFilter
+	userFilter.Id = user.Id
+	userFilter.IncludeDeleted = true
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if users.TotalRows == 0 {
+		return me.User{}, mo.ErrorUserNotFound
+	}
+	dbUser := users.Users[0]
+	if err := a.CheckUserRestore(&dbUser); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var userEmailCheckFilter me.UserFilter
+	userEmailCheckFilter.Email = dbUser.Email
+	emailExistsUsers, err := a.DbPort.GetUsersByFilter(ctx, userEmailCheckFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if emailExistsUsers.TotalRows > 0 {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		err := mo.ErrorUserEmailIsExists
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var userNameCheckFilter me.UserFilter
+	userNameCheckFilter.UserName = dbUser.UserName
+	nameExistsUsers, err := a.DbPort.GetUsersByFilter(ctx, userNameCheckFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if nameExistsUsers.TotalRows > 0 {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		err := mo.ErrorUserUsernameIsExists
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var userStatusHistoryFilter me.UserStatusHistoryFilter
+	userStatusHistoryFilter.UserId = dbUser.Id
+	userStatusHistoryFilter.Limit = 1
+	userStatusHistories, err := a.DbPort.GetUserStatusHistoryByFilter(ctx, userStatusHistoryFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var lastUserStatusHistory *me.UserStatusHistory
+	if len(userStatusHistories.UserStatusHistories) > 0 {
+		lastUserStatusHistory = &userStatusHistories.UserStatusHistories[0]
+	}
+	toStatus := a.GetRestoredUserStatus(lastUserStatusHistory)
+	err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = a.DbPort.RestoreUser(ctx, dbUser)
+		if err != nil {
+			return err
+		}
+		user.UserStatus = toStatus
+		user, err = a.DbPort.SaveUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		if err := a.recordUserStatusTransition(ctx, user.Id, mo.UserStatusDELETED, toStatus, "restored"); err != nil {
+			return err
+		}
+		return a.saveUserEvent(ctx, mo.UserEventTypeRESTORED, dbUser, user)
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return me.User{}, err
+	}
+
+	// The password is kept in the database on deletion, only its redis copy is removed.
+	userPassword, err := a.DbPort.GetUserPasswordByUserId(ctx, user.Id)
+	if err != nil && err != mo.ErrorUserPasswordNotFound {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+		return user, err
+	}
+	if err == nil {
+		if err := a.RedisPort.ChangePassword(ctx, userPassword); err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, err.Error()))
+			return user, err
+		}
+	}
+	return user, nil
+}
+
+// EraseUser sends the given user to the repository of the infrastructure layer for irreversibly anonymizing its personal data.
+// The user is kept as an anonymized tombstone, so that the audit trail still resolves its id.
+// The sessions of the user are kept by the auth service, they are purged by the downstream services on the erasure event.
+func (a *Service) EraseUser(ctx context.Context, user me.User) (me.User, error) {
+	if user.Id.String() == "" || user.Id == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	var userFilter me.UserFilter
+	userFilter.Id = user.Id
+	userFilter.IncludeDeleted = true
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	if users.TotalRows == 0 {
+		return me.User{}, mo.ErrorUserNotFound
+	}
+	dbUser := users.Users[0]
+	if !dbUser.ErasedAt.IsZero() {
+		err := mo.ErrorUserIsErased
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return me.User{}, err
+	}
+	erasedUser := a.GetErasedUser(&dbUser)
+	err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = a.DbPort.EraseUser(ctx, erasedUser)
+		if err != nil {
+			return err
+		}
+		if dbUser.UserStatus != mo.UserStatusDELETED {
+			if err := a.recordUserStatusTransition(ctx, user.Id, dbUser.UserStatus, mo.UserStatusDELETED, "erased"); err != nil {
+				return err
+			}
+		}
+		return a.saveUserEvent(ctx, mo.UserEventTypeERASED, dbUser, user)
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return me.User{}, err
+	}
+
+	err = a.RedisPort.DeleteUserPasswordByUserId(ctx, user.Id)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return user, err
+	}
+	err = a.RedisPort.DeleteUserActivitiesByUserId(ctx, user.Id)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, err.Error()))
+		return user, err
+	}
+	return user, nil
+}
+
 // ChangePassword sends the given user password to the repository of the infrastructure layer for changing user password.
 func (a *Service) ChangePassword(ctx context.Context, userPassword me.UserPassword) error {
 	if userPassword.UserId.String() == "" || userPassword.UserId == (uuid.UUID{}) {
@@ -197,15 +445,31 @@ func (a *Service) ChangePassword(ctx context.Context, userPassword me.UserPasswo
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangePassword", userId, err.Error()))
 		return err
 	}
-	passByte, err := bcrypt.GenerateFromPassword([]byte(userPassword.Password), 4)
+	hashedPassword, err := hashPassword(userPassword.Password)
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangePassword", userId, err.Error()))
 		return err
 	}
-	userPassword.Password = string(passByte)
+	userPassword.Password = hashedPassword
 	userPassword.PasswordStatus = mo.PasswordStatusACTIVE
-	userPassword, err = a.DbPort.ChangePassword(ctx, userPassword)
+	// The password itself is never published, the event only carries the user.
+	var userFilter me.UserFilter
+	userFilter.Id = userPassword.UserId
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangePassword", userId, err.Error()))
+		return err
+	}
+	if users.TotalRows == 0 {
+		return mo.ErrorUserNotFound
+	}
+	err = a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		userPassword, err = a.saveUserPassword(ctx, users.Users[0], userPassword)
+		return err
+	})
 	if err != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangePassword", userId, err.Error()))
@@ -217,7 +481,25 @@ func (a *Service) ChangePassword(ctx context.Context, userPassword me.UserPasswo
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangePassword", userId, err.Error()))
 		return err
 	}
-	return err
+	return nil
+}
+
+// saveUserPassword saves the given hashed password as the active password of the user, it must be called in a transaction.
+func (a *Service) saveUserPassword(ctx context.Context, user me.User, userPassword me.UserPassword) (me.UserPassword, error) {
+	userPassword, err := a.DbPort.ChangePassword(ctx, userPassword)
+	if err != nil {
+		return me.UserPassword{}, err
+	}
+	return userPassword, a.saveUserEvent(ctx, mo.UserEventTypePASSWORDCHANGED, user, user)
+}
+
+// hashPassword returns the hash of the given password to be stored.
+func hashPassword(password string) (string, error) {
+	passByte, err := bcrypt.GenerateFromPassword([]byte(password), 4)
+	if err != nil {
+		return "", err
+	}
+	return string(passByte), nil
 }
 
 // GetUserPasswordByUserId returns active password of the given user.
@@ -0,0 +1,327 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// userBatchCommand is a command applied to each user of a batch.
+type userBatchCommand struct {
+	name   string                                                                   // name is the name of the command in the logs.
+	apply  func(user *me.User) error                                                // apply changes the user and checks the change.
+	save   func(ctx context.Context, before me.User, user me.User) (me.User, error) // save saves the changed user, it is called in a transaction.
+	commit func(ctx context.Context, user me.User) error                            // commit is called after the saved user is committed, it is optional.
+}
+
+// BatchUpdateUserStatus moves the users of the given batch to the given status if the lifecycle allows it.
+// The users can not be deleted by a status change, BatchDeleteUsers must be used instead.
+func (a *Service) BatchUpdateUserStatus(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	if userBatch.UserStatus == mo.UserStatusDELETED {
+		err := mo.ErrorUserStatusDeletedIsNotAllowed
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "BatchUpdateUserStatus", userId, err.Error()))
+		return me.UserBatchResults{}, err
+	}
+	now := time.Now()
+	return a.runUserBatch(ctx, userBatch, userBatchCommand{
+		name: "BatchUpdateUserStatus",
+		apply: func(user *me.User) error {
+			userStatusChange := me.NewUserStatusChange(user.Id, userBatch.UserStatus, userBatch.Reason, userBatch.SuspendedUntil)
+			if err := userStatusChange.Validate(); err != nil {
+				return err
+			}
+			if err := a.CheckUserStatusTransition(user.UserStatus, userStatusChange.UserStatus, userStatusChange.Reason); err != nil {
+				return err
+			}
+			if err := a.CheckSuspensionRules(userStatusChange, now); err != nil {
+				return err
+			}
+			user.UserStatus = userStatusChange.UserStatus
+			user.SuspendedUntil = userStatusChange.SuspendedUntil
+			return a.ValidateUser(user)
+		},
+		save: func(ctx context.Context, before me.User, user me.User) (me.User, error) {
+			return a.saveUserStatusChange(ctx, before, user, userBatch.Reason)
+		},
+	})
+}
+
+// BatchUpdateUserRole changes the role of the users of the given batch.
+func (a *Service) BatchUpdateUserRole(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.runUserBatch(ctx, userBatch, userBatchCommand{
+		name: "BatchUpdateUserRole",
+		apply: func(user *me.User) error {
+			user.Role = userBatch.Role
+			return a.ValidateUser(user)
+		},
+		save: func(ctx context.Context, before me.User, user me.User) (me.User, error) {
+			return a.saveUpdatedUser(ctx, mo.UserEventTypeROLECHANGED, before, user)
+		},
+	})
+}
+
+// BatchAddTags adds the given tags to the users of the given batch.
+func (a *Service) BatchAddTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	if len(userBatch.Tags) == 0 {
+		err := mo.ErrorUserBatchTagsIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "BatchAddTags", userId, err.Error()))
+		return me.UserBatchResults{}, err
+	}
+	return a.runUserBatch(ctx, userBatch, userBatchCommand{
+		name: "BatchAddTags",
+		apply: func(user *me.User) error {
+			a.AddUserTags(user, userBatch.Tags)
+			return a.ValidateUser(user)
+		},
+		save: func(ctx context.Context, before me.User, user me.User) (me.User, error) {
+			return a.saveUpdatedUser(ctx, mo.UserEventTypeUPDATED, before, user)
+		},
+	})
+}
+
+// BatchRemoveTags removes the given tags from the users of the given batch.
+func (a *Service) BatchRemoveTags(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	if len(userBatch.Tags) == 0 {
+		err := mo.ErrorUserBatchTagsIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "BatchRemoveTags", userId, err.Error()))
+		return me.UserBatchResults{}, err
+	}
+	return a.runUserBatch(ctx, userBatch, userBatchCommand{
+		name: "BatchRemoveTags",
+		apply: func(user *me.User) error {
+			a.RemoveUserTags(user, userBatch.Tags)
+			return a.ValidateUser(user)
+		},
+		save: func(ctx context.Context, before me.User, user me.User) (me.User, error) {
+			return a.saveUpdatedUser(ctx, mo.UserEventTypeUPDATED, before, user)
+		},
+	})
+}
+
+// BatchDeleteUsers soft-deletes the users of the given batch.
+func (a *Service) BatchDeleteUsers(ctx context.Context, userBatch me.UserBatch) (me.UserBatchResults, error) {
+	return a.runUserBatch(ctx, userBatch, userBatchCommand{
+		name: "BatchDeleteUsers",
+		apply: func(user *me.User) error {
+			if err := a.CheckUserStatusTransition(user.UserStatus, mo.UserStatusDELETED, ""); err != nil {
+				return err
+			}
+			user.UserStatus = mo.UserStatusDELETED
+			return nil
+		},
+		save: a.saveDeletedUser,
+		commit: func(ctx context.Context, user me.User) error {
+			return a.RedisPort.DeleteUserPasswordByUserId(ctx, user.Id)
+		},
+	})
+}
+
+// runUserBatch applies the given command to the users of the given batch and returns the outcome of each user.
+// The transactional mode applies the command to all the users in one transaction if it can be applied to every user,
+// the best-effort mode applies the command to each user in its own transaction.
+func (a *Service) runUserBatch(ctx context.Context, userBatch me.UserBatch, command userBatchCommand) (me.UserBatchResults, error) {
+	userIds, err := a.getUserBatchIds(ctx, userBatch)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, command.name, userId, err.Error()))
+		return me.UserBatchResults{}, err
+	}
+	var results []me.UserBatchResult
+	if userBatch.UserBatchMode == mo.UserBatchModeBESTEFFORT {
+		results, err = a.runUserBatchBestEffort(ctx, userIds, command)
+	} else {
+		results, err = a.runUserBatchTransactional(ctx, userIds, command)
+	}
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, command.name, userId, err.Error()))
+		return me.UserBatchResults{}, err
+	}
+	userBatchResults := me.UserBatchResults{
+		UserBatchResults: results,
+	}
+	for _, result := range results {
+		if result.Error == "" {
+			userBatchResults.Succeeded++
+		} else {
+			userBatchResults.Failed++
+		}
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, command.name, userId, fmt.Sprintf("%v users succeeded, %v users failed", userBatchResults.Succeeded, userBatchResults.Failed)))
+	return userBatchResults, nil
+}
+
+// runUserBatchTransactional applies the command to all the users in one transaction, nothing is saved if the command fails for any user.
+// The users are read in chunks with their rows locked inside the transaction, so no concurrent write is lost between the read and the save.
+func (a *Service) runUserBatchTransactional(ctx context.Context, userIds []uuid.UUID, command userBatchCommand) ([]me.UserBatchResult, error) {
+	results := make([]me.UserBatchResult, len(userIds))
+	for i, userId := range userIds {
+		results[i] = *me.NewUserBatchResult(userId, me.User{}, "")
+	}
+	failed := false
+	err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		for start := 0; start < len(userIds); start += userBatchChunkSize() {
+			end := start + userBatchChunkSize()
+			if end > len(userIds) {
+				end = len(userIds)
+			}
+			dbUsers, err := a.getUserBatchUsers(ctx, userIds[start:end])
+			if err != nil {
+				return err
+			}
+			for i := start; i < end; i++ {
+				dbUser, ok := dbUsers[userIds[i]]
+				if !ok {
+					results[i].Error = mo.ErrorUserNotFound.Error()
+					failed = true
+					continue
+				}
+				user := dbUser
+				if err := command.apply(&user); err != nil {
+					results[i].Error = err.Error()
+					failed = true
+					continue
+				}
+				// The rest of the users are only checked once the batch has failed, the transaction is rolled back anyway.
+				if failed {
+					continue
+				}
+				user, err := command.save(ctx, dbUser, user)
+				if err != nil {
+					results[i].Error = err.Error()
+					failed = true
+					return err
+				}
+				results[i].User = user
+			}
+		}
+		if failed {
+			return mo.ErrorUserBatchIsRolledBack
+		}
+		return nil
+	})
+	if err != nil && !failed {
+		return nil, err
+	}
+	for i := range results {
+		if failed {
+			if results[i].Error == "" {
+				results[i].Error = mo.ErrorUserBatchIsRolledBack.Error()
+			}
+			results[i].User = me.User{}
+			continue
+		}
+		a.commitUserBatchItem(ctx, command, results[i].User)
+	}
+	return results, nil
+}
+
+// runUserBatchBestEffort applies the command to each user in its own transaction, the user is read with its row locked inside the transaction.
+func (a *Service) runUserBatchBestEffort(ctx context.Context, userIds []uuid.UUID, command userBatchCommand) ([]me.UserBatchResult, error) {
+	results := make([]me.UserBatchResult, len(userIds))
+	for i, userId := range userIds {
+		results[i] = *me.NewUserBatchResult(userId, me.User{}, "")
+		var user me.User
+		err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+			dbUsers, err := a.getUserBatchUsers(ctx, []uuid.UUID{userId})
+			if err != nil {
+				return err
+			}
+			dbUser, ok := dbUsers[userId]
+			if !ok {
+				return mo.ErrorUserNotFound
+			}
+			user = dbUser
+			if err := command.apply(&user); err != nil {
+				return err
+			}
+			user, err = command.save(ctx, dbUser, user)
+			return err
+		})
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].User = user
+		a.commitUserBatchItem(ctx, command, user)
+	}
+	return results, nil
+}
+
+// commitUserBatchItem calls the commit of the command for the given committed user, the failure is only logged.
+func (a *Service) commitUserBatchItem(ctx context.Context, command userBatchCommand, user me.User) {
+	if command.commit == nil {
+		return
+	}
+	if err := command.commit(ctx, user); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, command.name, userId, fmt.Sprintf("%v: %v", user.Id, err.Error())))
+	}
+}
+
+// getUserBatchIds returns the distinct ids of the users of the given batch, the filter is only used if no id is given.
+func (a *Service) getUserBatchIds(ctx context.Context, userBatch me.UserBatch) ([]uuid.UUID, error) {
+	maxSize := tconfig.GetServiceConfigInstance().Batch.MaxSize
+	userIds := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	add := func(userId uuid.UUID) error {
+		if userId == (uuid.UUID{}) || seen[userId] {
+			return nil
+		}
+		if maxSize > 0 && len(userIds) >= maxSize {
+			return mo.ErrorUserBatchIsTooLong
+		}
+		seen[userId] = true
+		userIds = append(userIds, userId)
+		return nil
+	}
+	if len(userBatch.UserIds) > 0 {
+		for _, userId := range userBatch.UserIds {
+			if err := add(userId); err != nil {
+				return nil, err
+			}
+		}
+	} else if userBatch.UserFilter != nil {
+		err := a.DbPort.StreamUsersByFilter(ctx, *userBatch.UserFilter, func(user me.User) error {
+			return add(user.Id)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, mo.ErrorUserBatchIsEmpty
+	}
+	return userIds, nil
+}
+
+// getUserBatchUsers returns the users of the given ids by their ids and locks them until the end of the transaction, the missing users are not returned.
+func (a *Service) getUserBatchUsers(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]me.User, error) {
+	users, err := a.DbPort.GetUsersForUpdate(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	dbUsers := make(map[uuid.UUID]me.User, len(users.Users))
+	for _, user := range users.Users {
+		dbUsers[user.Id] = user
+	}
+	return dbUsers, nil
+}
+
+// userBatchChunkSize returns the number of the users read at once by the batch commands and the imports.
+func userBatchChunkSize() int {
+	if chunkSize := tconfig.GetServiceConfigInstance().Batch.ChunkSize; chunkSize > 0 {
+		return chunkSize
+	}
+	return 500
+}
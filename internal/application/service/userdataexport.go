@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// ExportUserData returns everything the service holds about the given user for a subject access request.
+// The soft-deleted users are exported as well, the export itself is recorded as a read of the personal data of the user.
+func (a *Service) ExportUserData(ctx context.Context, userId uuid.UUID) (me.UserDataExport, error) {
+	if userId.String() == "" || userId == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	var userFilter me.UserFilter
+	userFilter.Id = userId
+	userFilter.IncludeDeleted = true
+	users, err := a.DbPort.GetUsersByFilter(ctx, userFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	if users.TotalRows == 0 {
+		return me.UserDataExport{}, mo.ErrorUserNotFound
+	}
+	var userStatusHistoryFilter me.UserStatusHistoryFilter
+	userStatusHistoryFilter.UserId = userId
+	userStatusHistories, err := a.DbPort.GetUserStatusHistoryByFilter(ctx, userStatusHistoryFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	var userLoginHistoryFilter me.UserLoginHistoryFilter
+	userLoginHistoryFilter.UserId = userId
+	userLoginHistories, err := a.DbPort.GetUserLoginHistoryByFilter(ctx, userLoginHistoryFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	var auditFilter me.AuditFilter
+	auditFilter.TargetId = userId
+	auditEntries, err := a.DbPort.GetAuditTrailByFilter(ctx, auditFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	var userReadAccessFilter me.UserReadAccessFilter
+	userReadAccessFilter.UserId = userId
+	userReadAccesses, err := a.DbPort.GetUserReadAccessesByFilter(ctx, userReadAccessFilter)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUserData", userId, err.Error()))
+		return me.UserDataExport{}, err
+	}
+	a.recordUserReadAccess(ctx, "ExportUserData", userFilter, users.Users)
+	return *me.NewUserDataExport(users.Users[0],
+		userStatusHistories.UserStatusHistories,
+		userLoginHistories.UserLoginHistories,
+		auditEntries.AuditEntries,
+		userReadAccesses.UserReadAccesses,
+		time.Now()), nil
+}
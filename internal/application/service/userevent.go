@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// saveUserEvent writes the given mutation of the user to the outbox and the audit trail on behalf of the actor in the context.
+// It must be called in the transaction of the mutation, so that the event is only sent if the mutation is committed.
+// The erasure event carries only the anonymized tombstone, the erased personal data is not sent.
+func (a *Service) saveUserEvent(ctx context.Context, userEventType mo.UserEventType, before me.User, after me.User) error {
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	actorId, _ := uuid.Parse(userId)
+	correlationId, _ := ctx.Value(mo.QueryKeyCorrelationId).(string)
+	eventBefore := before
+	if userEventType == mo.UserEventTypeERASED {
+		eventBefore = after
+	}
+	userEvent := me.NewUserEvent(uuid.New(), userEventType, eventBefore, after, actorId, time.Now(), correlationId)
+	outboxEvent := me.NewOutboxEvent(uuid.UUID{}, *userEvent)
+	outboxEvent.NextAttemptAt = userEvent.OccurredAt
+	if err := a.DbPort.SaveOutboxEvent(ctx, *outboxEvent); err != nil {
+		return err
+	}
+	return a.saveUserAuditEntry(ctx, userEventType, before, after)
+}
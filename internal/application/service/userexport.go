@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// userExportReadAccessBatchSize is the number of the exported users recorded in each read of the read audit.
+const userExportReadAccessBatchSize = 1000
+
+// ExportUsers calls the given function for each user that matches the given filter, the users are streamed from the repository.
+// The exported users are recorded in the read audit in batches, so the memory use does not grow with the number of the users.
+func (a *Service) ExportUsers(ctx context.Context, userFilter me.UserFilter, fn func(user me.User) error) error {
+	users := make([]me.User, 0, userExportReadAccessBatchSize)
+	err := a.DbPort.StreamUsersByFilter(ctx, userFilter, func(user me.User) error {
+		if err := fn(user); err != nil {
+			return err
+		}
+		users = append(users, user)
+		if len(users) == userExportReadAccessBatchSize {
+			a.recordUserReadAccess(ctx, "ExportUsers", userFilter, users)
+			users = make([]me.User, 0, userExportReadAccessBatchSize)
+		}
+		return nil
+	})
+	a.recordUserReadAccess(ctx, "ExportUsers", userFilter, users)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ExportUsers", userId, err.Error()))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	pb_notification "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// ImportUsers creates the users of the given import, each row is checked like CreateUser and against the earlier rows.
+// The rows are read in chunks by the given next function until it returns no row, only the outcome of each row is kept
+// except in the all-or-nothing mode which keeps the checked rows until they are saved.
+// A dry run only reports the checks. The all-or-nothing mode creates the users in one transaction if every row passes the checks,
+// the best-effort mode creates each valid user on its own and reports the rejected rows.
+func (a *Service) ImportUsers(ctx context.Context, userImport me.UserImport, next func(limit int) ([]me.UserImportRow, error)) (me.UserImportResults, error) {
+	rows, err := next(userBatchChunkSize())
+	if err == nil && len(rows) == 0 {
+		err = mo.ErrorUserImportIsEmpty
+	}
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ImportUsers", userId, err.Error()))
+		return me.UserImportResults{}, err
+	}
+	// The first chunk is already read, it is handed out before the rest.
+	nextChunk := func() ([]me.UserImportRow, error) {
+		if rows != nil {
+			chunk := rows
+			rows = nil
+			return chunk, nil
+		}
+		return next(userBatchChunkSize())
+	}
+	var results []me.UserImportResult
+	switch {
+	case userImport.DryRun:
+		results, err = a.checkUserImport(ctx, nextChunk)
+	case userImport.UserImportMode == mo.UserImportModeBESTEFFORT:
+		results, err = a.importUsersBestEffort(ctx, nextChunk)
+	default:
+		results, err = a.importUsersAllOrNothing(ctx, nextChunk)
+	}
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ImportUsers", userId, err.Error()))
+		return me.UserImportResults{}, err
+	}
+	userImportResults := newUserImportResults(results, userImport.DryRun)
+	if !userImport.DryRun {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeINFO, "ImportUsers", userId, fmt.Sprintf("%v users imported, %v rows failed", userImportResults.Imported, userImportResults.Failed)))
+	}
+	return userImportResults, nil
+}
+
+// checkUserImport checks the rows of the chunks returned by next without creating the users.
+func (a *Service) checkUserImport(ctx context.Context, next func() ([]me.UserImportRow, error)) ([]me.UserImportResult, error) {
+	results := []me.UserImportResult{}
+	emails := map[string]bool{}
+	userNames := map[string]bool{}
+	for {
+		rows, err := next()
+		if err != nil || len(rows) == 0 {
+			return results, err
+		}
+		for _, row := range rows {
+			result := *me.NewUserImportResult(row.Line, uuid.UUID{}, "", false)
+			if err := a.checkUserImportRow(ctx, &row, emails, userNames); err != nil {
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+}
+
+// importUsersBestEffort creates each valid user of the chunks returned by next in its own transaction.
+func (a *Service) importUsersBestEffort(ctx context.Context, next func() ([]me.UserImportRow, error)) ([]me.UserImportResult, error) {
+	results := []me.UserImportResult{}
+	emails := map[string]bool{}
+	userNames := map[string]bool{}
+	for {
+		rows, err := next()
+		if err != nil || len(rows) == 0 {
+			return results, err
+		}
+		for _, row := range rows {
+			result := *me.NewUserImportResult(row.Line, uuid.UUID{}, "", false)
+			if err := a.checkUserImportRow(ctx, &row, emails, userNames); err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			var user me.User
+			var userPassword me.UserPassword
+			err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				user, userPassword, err = a.saveUserImportRow(ctx, row)
+				return err
+			})
+			if err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			result.UserId = user.Id
+			result.Invited = a.commitUserImportRow(ctx, row, user, userPassword)
+			results = append(results, result)
+		}
+	}
+}
+
+// userImportCommit is a checked row of an all-or-nothing import that is saved in the transaction, then cached and invited after the commit.
+type userImportCommit struct {
+	index        int              // index is the index of the outcome of the row.
+	row          me.UserImportRow // row is the imported row.
+	user         me.User          // user is the created user.
+	userPassword me.UserPassword  // userPassword is the initial password of the user.
+}
+
+// importUsersAllOrNothing creates the users of the chunks returned by next in one transaction, nothing is saved if any row fails.
+// Every row is read and checked before the transaction is opened so that a slow reader can not hold the transaction,
+// the import is refused if it has more rows than the batch maximum size.
+func (a *Service) importUsersAllOrNothing(ctx context.Context, next func() ([]me.UserImportRow, error)) ([]me.UserImportResult, error) {
+	maxSize := tconfig.GetServiceConfigInstance().Batch.MaxSize
+	results := []me.UserImportResult{}
+	emails := map[string]bool{}
+	userNames := map[string]bool{}
+	commits := []userImportCommit{}
+	rejected := false
+	for {
+		rows, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		if maxSize > 0 && len(results)+len(rows) > maxSize {
+			return nil, mo.ErrorUserImportIsTooLong
+		}
+		for _, row := range rows {
+			result := *me.NewUserImportResult(row.Line, uuid.UUID{}, "", false)
+			if err := a.checkUserImportRow(ctx, &row, emails, userNames); err != nil {
+				result.Error = err.Error()
+				rejected = true
+			} else if !rejected {
+				// The rest of the rows are only checked once a row is rejected, nothing is saved anyway.
+				commits = append(commits, userImportCommit{index: len(results), row: row})
+			}
+			results = append(results, result)
+		}
+	}
+	if rejected {
+		return rollBackUserImport(results), nil
+	}
+	var saveErr error
+	err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		for i, commit := range commits {
+			user, userPassword, err := a.saveUserImportRow(ctx, commit.row)
+			if err != nil {
+				results[commit.index].Error = err.Error()
+				saveErr = err
+				return err
+			}
+			results[commit.index].UserId = user.Id
+			commits[i].user = user
+			commits[i].userPassword = userPassword
+		}
+		return nil
+	})
+	if err != nil && saveErr == nil {
+		return nil, err
+	}
+	if err != nil {
+		return rollBackUserImport(results), nil
+	}
+	for _, commit := range commits {
+		if commit.userPassword.UserId != (uuid.UUID{}) || commit.row.Invite {
+			results[commit.index].Invited = a.commitUserImportRow(ctx, commit.row, commit.user, commit.userPassword)
+		}
+	}
+	return results, nil
+}
+
+// rollBackUserImport clears the created users of the given outcomes and marks the rows without an error as rolled back.
+func rollBackUserImport(results []me.UserImportResult) []me.UserImportResult {
+	for i := range results {
+		results[i].UserId = uuid.UUID{}
+		if results[i].Error == "" {
+			results[i].Error = mo.ErrorUserImportIsRolledBack.Error()
+		}
+	}
+	return results
+}
+
+// commitUserImportRow caches the password and sends the invitation of the given committed user, it returns true if the user is invited.
+func (a *Service) commitUserImportRow(ctx context.Context, row me.UserImportRow, user me.User, userPassword me.UserPassword) bool {
+	if userPassword.UserId != (uuid.UUID{}) {
+		if err := a.RedisPort.ChangePassword(ctx, userPassword); err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ImportUsers", userId, err.Error()))
+		}
+	}
+	return row.Invite && a.inviteUser(ctx, user) == nil
+}
+
+// checkUserImportRow checks the user of the given row like CreateUser and against the users of the earlier rows.
+// The invited user is pending if its status is not given.
+func (a *Service) checkUserImportRow(ctx context.Context, row *me.UserImportRow, emails map[string]bool, userNames map[string]bool) error {
+	row.User.Id = uuid.UUID{}
+	if row.Invite && row.User.UserStatus == mo.UserStatusNONE {
+		row.User.UserStatus = mo.UserStatusPENDING
+	}
+	if err := a.checkNewUser(ctx, &row.User); err != nil {
+		return err
+	}
+	if emails[row.User.Email] {
+		return mo.ErrorUserEmailIsExists
+	}
+	if userNames[row.User.UserName] {
+		return mo.ErrorUserUsernameIsExists
+	}
+	emails[row.User.Email] = true
+	userNames[row.User.UserName] = true
+	if row.Password != "" {
+		userPassword := me.NewEmptyUserPassword()
+		userPassword.Password = row.Password
+		if err := a.CheckPasswordRules(userPassword); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveUserImportRow saves the user of the given checked row with its initial password, it must be called in a transaction.
+func (a *Service) saveUserImportRow(ctx context.Context, row me.UserImportRow) (me.User, me.UserPassword, error) {
+	user, err := a.saveNewUser(ctx, row.User)
+	if err != nil || row.Password == "" {
+		return user, me.UserPassword{}, err
+	}
+	hashedPassword, err := hashPassword(row.Password)
+	if err != nil {
+		return me.User{}, me.UserPassword{}, err
+	}
+	userPassword := me.NewEmptyUserPassword()
+	userPassword.UserId = user.Id
+	userPassword.Password = hashedPassword
+	userPassword.PasswordStatus = mo.PasswordStatusACTIVE
+	savedUserPassword, err := a.saveUserPassword(ctx, user, *userPassword)
+	if err != nil {
+		return me.User{}, me.UserPassword{}, err
+	}
+	return user, savedUserPassword, nil
+}
+
+// inviteUser sends the invitation to the given imported user.
+func (a *Service) inviteUser(ctx context.Context, user me.User) error {
+	message := "You are invited to join. Please complete your registration to activate your account."
+	_, err := a.Notify(ctx, me.NewNotificationData().GenerateNotificationData(pb_notification.NotificationType_NotificationTypeEMAIL, user.Id.String(), user.Email, "Invitation", message))
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ImportUsers", userId, fmt.Sprintf("%v: %v", user.Id, err.Error())))
+	}
+	return err
+}
+
+// newUserImportResults returns the given row outcomes with the number of the imported and the failed rows.
+func newUserImportResults(results []me.UserImportResult, dryRun bool) me.UserImportResults {
+	userImportResults := me.UserImportResults{
+		UserImportResults: results,
+		DryRun:            dryRun,
+	}
+	for _, result := range results {
+		if result.UserId != (uuid.UUID{}) {
+			userImportResults.Imported++
+		}
+		if result.Error != "" {
+			userImportResults.Failed++
+		}
+	}
+	return userImportResults
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	pb_notification "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+	ip_repo "github.com/octoposprime/op-be-user/internal/application/infrastructure/port/repository"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	ds "github.com/octoposprime/op-be-user/internal/domain/service"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+var errUserImportSave = errors.New("save failed")
+
+// userImportDb is a DbPort of the import tests, the users saved in a failed transaction are dropped.
+type userImportDb struct {
+	ip_repo.DbPort
+	users    []me.User // users is the committed users.
+	pending  []me.User // pending is the users saved in the open transaction.
+	inTx     bool      // inTx is true while a transaction is open.
+	failOn   string    // failOn is the email of the user whose save fails.
+	txCalled bool      // txCalled is true if a transaction is opened.
+}
+
+func (d *userImportDb) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	d.txCalled = true
+	d.inTx = true
+	err := fn(ctx)
+	d.inTx = false
+	if err == nil {
+		d.users = append(d.users, d.pending...)
+	}
+	d.pending = nil
+	return err
+}
+
+func (d *userImportDb) GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
+	return me.Users{}, nil
+}
+
+func (d *userImportDb) SaveUser(ctx context.Context, user me.User) (me.User, error) {
+	if user.Email == d.failOn {
+		return me.User{}, errUserImportSave
+	}
+	user.Id = uuid.New()
+	d.pending = append(d.pending, user)
+	return user, nil
+}
+
+func (d *userImportDb) SaveUserStatusHistory(ctx context.Context, userStatusHistory me.UserStatusHistory) (me.UserStatusHistory, error) {
+	return userStatusHistory, nil
+}
+
+func (d *userImportDb) ChangePassword(ctx context.Context, userPassword me.UserPassword) (me.UserPassword, error) {
+	return userPassword, nil
+}
+
+func (d *userImportDb) SaveOutboxEvent(ctx context.Context, outboxEvent me.OutboxEvent) error {
+	return nil
+}
+
+func (d *userImportDb) SaveAuditEntry(ctx context.Context, auditEntry me.AuditEntry) (me.AuditEntry, error) {
+	return auditEntry, nil
+}
+
+// userImportRedis is a RedisPort of the import tests.
+type userImportRedis struct {
+	ip_repo.RedisPort
+}
+
+func (r *userImportRedis) ChangePassword(ctx context.Context, userPassword me.UserPassword) error {
+	return nil
+}
+
+// userImportServices is a ServicePort of the import tests, it keeps the invited users.
+type userImportServices struct {
+	invited []string
+}
+
+func (s *userImportServices) Log(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error) {
+	return &pb_logging.LoggingResult{}, nil
+}
+
+func (s *userImportServices) Notify(ctx context.Context, notificationData *pb_notification.NotificationData) (*pb_notification.NotificationResult, error) {
+	s.invited = append(s.invited, notificationData.Header.UserId)
+	return &pb_notification.NotificationResult{}, nil
+}
+
+func newUserImportRow(line int, userName string, email string, password string, invite bool) me.UserImportRow {
+	user := me.NewEmptyUser()
+	user.UserName = userName
+	user.Email = email
+	user.Role = "USER"
+	return *me.NewUserImportRow(line, *user, password, invite)
+}
+
+func TestService_ImportUsers(t *testing.T) {
+	rows := []me.UserImportRow{
+		newUserImportRow(1, "first_user", "first@example.com", "Password1", false),
+		newUserImportRow(2, "second_user", "second@example.com", "", true),
+		newUserImportRow(3, "third_user", "third@example.com", "", false),
+	}
+	rejectedRows := []me.UserImportRow{
+		rows[0],
+		newUserImportRow(2, "second_user", "not-an-email", "", true),
+		rows[2],
+	}
+	tests := []struct {
+		name        string
+		userImport  me.UserImport
+		rows        []me.UserImportRow
+		maxSize     int
+		failOn      string
+		wantErr     error
+		wantSaved   int
+		wantInvited int
+		wantErrors  []error
+	}{
+		{
+			name:       "Dry Run",
+			userImport: *me.NewUserImport(mo.UserImportModeALLORNOTHING, true),
+			rows:       rejectedRows,
+			wantErrors: []error{nil, mo.ErrorUserEmailIsNotValid, nil},
+		},
+		{
+			name:       "Best Effort",
+			userImport: *me.NewUserImport(mo.UserImportModeBESTEFFORT, false),
+			rows:       rejectedRows,
+			wantSaved:  2,
+			wantErrors: []error{nil, mo.ErrorUserEmailIsNotValid, nil},
+		},
+		{
+			name:        "All Or Nothing",
+			userImport:  *me.NewUserImport(mo.UserImportModeALLORNOTHING, false),
+			rows:        rows,
+			wantSaved:   3,
+			wantInvited: 1,
+			wantErrors:  []error{nil, nil, nil},
+		},
+		{
+			name:       "All Or Nothing Rolled Back By Rejected Row",
+			userImport: *me.NewUserImport(mo.UserImportModeALLORNOTHING, false),
+			rows:       rejectedRows,
+			wantErrors: []error{mo.ErrorUserImportIsRolledBack, mo.ErrorUserEmailIsNotValid, mo.ErrorUserImportIsRolledBack},
+		},
+		{
+			name:       "All Or Nothing Rolled Back By Failed Save",
+			userImport: *me.NewUserImport(mo.UserImportModeALLORNOTHING, false),
+			rows:       rows,
+			failOn:     "second@example.com",
+			wantErrors: []error{mo.ErrorUserImportIsRolledBack, errUserImportSave, mo.ErrorUserImportIsRolledBack},
+		},
+		{
+			name:       "All Or Nothing Too Long",
+			userImport: *me.NewUserImport(mo.UserImportModeALLORNOTHING, false),
+			rows:       rows,
+			maxSize:    2,
+			wantErr:    mo.ErrorUserImportIsTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tconfig.ServiceConfigInstance = &tconfig.ServiceConfig{}
+			tconfig.ServiceConfigInstance.Batch.MaxSize = tt.maxSize
+			db := &userImportDb{failOn: tt.failOn}
+			services := &userImportServices{}
+			a := &Service{Service: ds.NewService(), DbPort: db, RedisPort: &userImportRedis{}, ServicePort: services}
+			rows := append([]me.UserImportRow{}, tt.rows...)
+			readInTx := false
+			next := func(limit int) ([]me.UserImportRow, error) {
+				readInTx = readInTx || db.inTx
+				chunk := rows
+				rows = nil
+				return chunk, nil
+			}
+			got, err := a.ImportUsers(context.Background(), tt.userImport, next)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ImportUsers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if readInTx {
+				t.Errorf("ImportUsers() read the rows in the transaction")
+			}
+			if tt.userImport.DryRun && db.txCalled {
+				t.Errorf("ImportUsers() opened a transaction in a dry run")
+			}
+			if len(db.users) != tt.wantSaved {
+				t.Errorf("ImportUsers() saved %v users, want %v", len(db.users), tt.wantSaved)
+			}
+			if got.Imported != int64(tt.wantSaved) {
+				t.Errorf("ImportUsers() imported = %v, want %v", got.Imported, tt.wantSaved)
+			}
+			if len(services.invited) != tt.wantInvited {
+				t.Errorf("ImportUsers() invited %v users, want %v", len(services.invited), tt.wantInvited)
+			}
+			if len(got.UserImportResults) != len(tt.wantErrors) {
+				t.Fatalf("ImportUsers() returned %v results, want %v", len(got.UserImportResults), len(tt.wantErrors))
+			}
+			for i, result := range got.UserImportResults {
+				wantError := ""
+				if tt.wantErrors[i] != nil {
+					wantError = tt.wantErrors[i].Error()
+				}
+				if result.Error != wantError {
+					t.Errorf("ImportUsers() row %v error = %v, want %v", result.Line, result.Error, wantError)
+				}
+				if wantError != "" && result.UserId != (uuid.UUID{}) {
+					t.Errorf("ImportUsers() row %v has a user id although it failed", result.Line)
+				}
+			}
+		})
+	}
+}
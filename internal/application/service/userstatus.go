@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// ChangeUserStatus moves the user to the given status if the lifecycle allows it and records the transition.
+func (a *Service) ChangeUserStatus(ctx context.Context, userStatusChange me.UserStatusChange) (me.User, error) {
+	return a.changeUserStatus(ctx, userStatusChange, userStatusChange.Reason)
+}
+
+// changeUserStatus moves the user to the given status if the lifecycle allows it and records the transition with the given reason.
+// The user is read and locked in the transaction of the change, so the guards are checked against the status that is replaced.
+func (a *Service) changeUserStatus(ctx context.Context, userStatusChange me.UserStatusChange, recordedReason string) (me.User, error) {
+	if err := userStatusChange.Validate(); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangeUserStatus", userId, err.Error()))
+		return me.User{}, err
+	}
+	var dbUser me.User
+	err := a.DbPort.RunInTransaction(ctx, func(ctx context.Context) error {
+		users, err := a.DbPort.GetUsersForUpdate(ctx, []uuid.UUID{userStatusChange.UserId})
+		if err != nil {
+			return err
+		}
+		if len(users.Users) == 0 {
+			return mo.ErrorUserNotFound
+		}
+		before := users.Users[0]
+		if err := a.CheckUserStatusTransition(before.UserStatus, userStatusChange.UserStatus, userStatusChange.Reason); err != nil {
+			return err
+		}
+		if err := a.CheckSuspensionRules(&userStatusChange, time.Now()); err != nil {
+			return err
+		}
+		user := before
+		user.UserStatus = userStatusChange.UserStatus
+		// The suspension end only lives as long as the user is suspended.
+		user.SuspendedUntil = userStatusChange.SuspendedUntil
+		if err := a.ValidateUser(&user); err != nil {
+			return err
+		}
+		dbUser, err = a.saveUserStatusChange(ctx, before, user, recordedReason)
+		return err
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ChangeUserStatus", userId, err.Error()))
+		return me.User{}, err
+	}
+	return dbUser, nil
+}
+
+// GetUserStatusHistory returns the status transitions of the user that match the given filter.
+func (a *Service) GetUserStatusHistory(ctx context.Context, userStatusHistoryFilter me.UserStatusHistoryFilter) (me.UserStatusHistories, error) {
+	if userStatusHistoryFilter.UserId.String() == "" || userStatusHistoryFilter.UserId == (uuid.UUID{}) {
+		err := mo.ErrorUserIdIsEmpty
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserStatusHistory", userId, err.Error()))
+		return me.UserStatusHistories{}, err
+	}
+	return a.DbPort.GetUserStatusHistoryByFilter(ctx, userStatusHistoryFilter)
+}
+
+// saveUserStatusChange saves the given user in its new status with the status transition and its event, it must be called in a transaction.
+func (a *Service) saveUserStatusChange(ctx context.Context, before me.User, user me.User, reason string) (me.User, error) {
+	user, err := a.DbPort.SaveUser(ctx, user)
+	if err != nil {
+		return me.User{}, err
+	}
+	if err := a.recordUserStatusTransition(ctx, user.Id, before.UserStatus, user.UserStatus, reason); err != nil {
+		return me.User{}, err
+	}
+	return user, a.saveUserEvent(ctx, mo.UserEventTypeSTATUSCHANGED, before, user)
+}
+
+// recordUserStatusTransition writes the applied status transition of the user to the status history.
+func (a *Service) recordUserStatusTransition(ctx context.Context, userId uuid.UUID, fromStatus mo.UserStatus, toStatus mo.UserStatus, reason string) error {
+	_, err := a.DbPort.SaveUserStatusHistory(ctx, *me.NewUserStatusHistory(uuid.UUID{}, userId, fromStatus, toStatus, reason, uuid.UUID{}))
+	return err
+}
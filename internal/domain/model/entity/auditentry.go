@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// AuditEntry is a struct that represents the entity of a command recorded in the audit trail.
+// Each entry is chained to the previous one by its hash, so changing or removing an entry breaks the chain.
+type AuditEntry struct {
+	Id          uuid.UUID      `json:"id"`           // Id is the id of the entry.
+	Seq         int64          `json:"seq"`          // Seq is the position of the entry in the audit trail.
+	ActorId     uuid.UUID      `json:"actor_id"`     // ActorId is the id of the user who ran the command.
+	AuditAction mo.AuditAction `json:"audit_action"` // AuditAction is the action of the command.
+	TargetId    uuid.UUID      `json:"target_id"`    // TargetId is the id of the user the command is run on.
+	RequestId   string         `json:"request_id"`   // RequestId is the id of the request that ran the command.
+	Diff        string         `json:"diff"`         // Diff is the JSON of the changed fields of the target, or the details of the command if it has no target.
+	DiffHash    string         `json:"diff_hash"`    // DiffHash is the hash of the diff, it is kept when the diff is erased.
+	PrevHash    string         `json:"prev_hash"`    // PrevHash is the hash of the previous entry.
+	Hash        string         `json:"hash"`         // Hash is the hash of the entry.
+	CreatedAt   time.Time      `json:"created_at"`   // CreatedAt is the time of the command.
+}
+
+// NewAuditEntry creates a new *AuditEntry.
+func NewAuditEntry(id uuid.UUID,
+	actorId uuid.UUID,
+	auditAction mo.AuditAction,
+	targetId uuid.UUID,
+	requestId string,
+	diff string) *AuditEntry {
+	return &AuditEntry{
+		Id:          id,
+		ActorId:     actorId,
+		AuditAction: auditAction,
+		TargetId:    targetId,
+		RequestId:   requestId,
+		Diff:        diff,
+	}
+}
+
+// String returns a string representation of the AuditEntry.
+func (s *AuditEntry) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"Seq: %v, "+
+		"ActorId: %v, "+
+		"AuditAction: %v, "+
+		"TargetId: %v, "+
+		"RequestId: %v, "+
+		"Diff: %v, "+
+		"DiffHash: %v, "+
+		"PrevHash: %v, "+
+		"Hash: %v, "+
+		"CreatedAt: %v",
+		s.Id,
+		s.Seq,
+		s.ActorId,
+		s.AuditAction,
+		s.TargetId,
+		s.RequestId,
+		s.Diff,
+		s.DiffHash,
+		s.PrevHash,
+		s.Hash,
+		s.CreatedAt)
+}
+
+// ComputeDiffHash returns the hash of the diff of the entry.
+func (s *AuditEntry) ComputeDiffHash() string {
+	sum := sha256.Sum256([]byte(s.Diff))
+	return hex.EncodeToString(sum[:])
+}
+
+// ComputeHash returns the hash of the entry over its recorded values and the hash of the previous entry.
+// The diff takes part by its hash, so that the chain survives the erasure of the diff.
+func (s *AuditEntry) ComputeHash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		fmt.Sprint(s.Seq),
+		s.ActorId.String(),
+		fmt.Sprint(int(s.AuditAction)),
+		s.TargetId.String(),
+		s.RequestId,
+		s.DiffHash,
+		s.CreatedAt.UTC().Format(time.RFC3339Nano),
+		s.PrevHash,
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Chain sets the position, the time and the hashes of the entry to append it after the given previous entry.
+// The previous entry is nil for the first entry of the audit trail.
+func (s *AuditEntry) Chain(previous *AuditEntry, createdAt time.Time) {
+	s.Seq = 1
+	s.PrevHash = ""
+	if previous != nil {
+		s.Seq = previous.Seq + 1
+		s.PrevHash = previous.Hash
+	}
+	s.CreatedAt = createdAt
+	s.DiffHash = s.ComputeDiffHash()
+	s.Hash = s.ComputeHash()
+}
+
+// Verify returns true if the entry is not changed and it follows the given previous entry.
+// The previous entry can be nil if it is not known, then only the entry itself is checked.
+// An erased diff is empty, then only its hash is checked.
+func (s *AuditEntry) Verify(previous *AuditEntry) bool {
+	if s.Hash != s.ComputeHash() {
+		return false
+	}
+	if s.Diff != "" && s.DiffHash != s.ComputeDiffHash() {
+		return false
+	}
+	if previous != nil && (s.Seq != previous.Seq+1 || s.PrevHash != previous.Hash) {
+		return false
+	}
+	return true
+}
+
+// AuditEntries contains a slice of *AuditEntry and total number of records.
+type AuditEntries struct {
+	AuditEntries []AuditEntry `json:"audit_entries"` // AuditEntries is the slice of *AuditEntry.
+	TotalRows    int64        `json:"total_rows"`    // TotalRows is the total number of rows.
+}
+
+// AuditChange is a struct that represents the old and the new value of a changed field.
+type AuditChange struct {
+	Old interface{} `json:"old"` // Old is the value of the field before the command.
+	New interface{} `json:"new"` // New is the value of the field after the command.
+}
+
+// auditIgnoredFields are the fields of the user that are only for view, they are not recorded in the diff.
+var auditIgnoredFields = map[string]bool{
+	"created_at":         true,
+	"updated_at":         true,
+	"last_login_at":      true,
+	"last_activity_at":   true,
+	"dormancy_warned_at": true,
+}
+
+// NewUserDiff returns the changed fields of the user between the given states.
+func NewUserDiff(before User, after User) (map[string]AuditChange, error) {
+	beforeFields, err := userFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := userFields(after)
+	if err != nil {
+		return nil, err
+	}
+	diff := make(map[string]AuditChange)
+	for field, newValue := range afterFields {
+		if oldValue := beforeFields[field]; !reflect.DeepEqual(oldValue, newValue) {
+			diff[field] = AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, oldValue := range beforeFields {
+		if _, ok := afterFields[field]; !ok {
+			diff[field] = AuditChange{Old: oldValue, New: nil}
+		}
+	}
+	return diff, nil
+}
+
+// userFields returns the recorded fields of the user by their json names.
+func userFields(user User) (map[string]interface{}, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for field := range auditIgnoredFields {
+		delete(fields, field)
+	}
+	return fields, nil
+}
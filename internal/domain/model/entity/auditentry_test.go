@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// newTestAuditTrail returns a chain of the given number of audit entries.
+func newTestAuditTrail(length int) []AuditEntry {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditEntries := make([]AuditEntry, length)
+	var previous *AuditEntry
+	for i := range auditEntries {
+		auditEntries[i] = *NewAuditEntry(uuid.New(), uuid.New(), mo.AuditActionUPDATE, uuid.New(), "request", `{"first_name":{"old":"a","new":"b"}}`)
+		auditEntries[i].Chain(previous, createdAt.Add(time.Duration(i)*time.Second))
+		previous = &auditEntries[i]
+	}
+	return auditEntries
+}
+
+// verifyTestAuditTrail returns the position of the first entry of the given chain that does not verify, or 0 if all of them verify.
+func verifyTestAuditTrail(auditEntries []AuditEntry) int64 {
+	var previous *AuditEntry
+	for i := range auditEntries {
+		if !auditEntries[i].Verify(previous) {
+			return auditEntries[i].Seq
+		}
+		previous = &auditEntries[i]
+	}
+	return 0
+}
+
+func TestAuditEntry_Verify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(auditEntries []AuditEntry) []AuditEntry
+		want   int64
+	}{
+		{"ValidChain", func(auditEntries []AuditEntry) []AuditEntry {
+			return auditEntries
+		}, 0},
+		{"EditedField", func(auditEntries []AuditEntry) []AuditEntry {
+			auditEntries[2].ActorId = uuid.New()
+			return auditEntries
+		}, 3},
+		{"EditedDiff", func(auditEntries []AuditEntry) []AuditEntry {
+			auditEntries[2].Diff = `{"first_name":{"old":"a","new":"c"}}`
+			return auditEntries
+		}, 3},
+		{"EditedAndRehashedEntry", func(auditEntries []AuditEntry) []AuditEntry {
+			auditEntries[2].TargetId = uuid.New()
+			auditEntries[2].Hash = auditEntries[2].ComputeHash()
+			return auditEntries
+		}, 4},
+		{"RemovedEntry", func(auditEntries []AuditEntry) []AuditEntry {
+			return append(auditEntries[:2], auditEntries[3:]...)
+		}, 4},
+		{"ErasedDiff", func(auditEntries []AuditEntry) []AuditEntry {
+			auditEntries[2].Diff = ""
+			return auditEntries
+		}, 0},
+		{"ErasedDiffHash", func(auditEntries []AuditEntry) []AuditEntry {
+			auditEntries[2].Diff = ""
+			auditEntries[2].DiffHash = ""
+			return auditEntries
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyTestAuditTrail(tt.tamper(newTestAuditTrail(5))); got != tt.want {
+				t.Errorf("the chain breaks at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
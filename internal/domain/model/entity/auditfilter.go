@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// AuditFilter is a struct that represents the filter of the audit trail.
+type AuditFilter struct {
+	ActorId     uuid.UUID      `json:"actor_id"`     // ActorId is the id of the user who ran the command.
+	TargetId    uuid.UUID      `json:"target_id"`    // TargetId is the id of the user the command is run on.
+	AuditAction mo.AuditAction `json:"audit_action"` // AuditAction is the action of the command.
+
+	CreatedAtFrom time.Time `json:"created_at_from"` // CreatedAt is in the between of CreatedAtFrom and CreatedAtTo.
+	CreatedAtTo   time.Time `json:"created_at_to"`   // CreatedAt is in the between of CreatedAtFrom and CreatedAtTo.
+
+	Limit  int `json:"limit"`  // Limit provides to limitation row size.
+	Offset int `json:"offset"` // Offset provides a starting row number of the limitation.
+}
+
+// NewAuditFilter creates a new *AuditFilter.
+func NewAuditFilter(actorId uuid.UUID,
+	targetId uuid.UUID,
+	auditAction mo.AuditAction,
+	createdAtFrom time.Time,
+	createdAtTo time.Time,
+	limit int,
+	offset int) *AuditFilter {
+	return &AuditFilter{
+		ActorId:       actorId,
+		TargetId:      targetId,
+		AuditAction:   auditAction,
+		CreatedAtFrom: createdAtFrom,
+		CreatedAtTo:   createdAtTo,
+		Limit:         limit,
+		Offset:        offset,
+	}
+}
+
+// String returns a string representation of the AuditFilter.
+func (s *AuditFilter) String() string {
+	return fmt.Sprintf("ActorId: %v, "+
+		"TargetId: %v, "+
+		"AuditAction: %v, "+
+		"CreatedAtFrom: %v, "+
+		"CreatedAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.ActorId,
+		s.TargetId,
+		s.AuditAction,
+		s.CreatedAtFrom,
+		s.CreatedAtTo,
+		s.Limit,
+		s.Offset)
+}
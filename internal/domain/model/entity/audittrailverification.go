@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// AuditTrailVerification is a struct that represents the entity of the outcome of a verification of the audit trail.
+type AuditTrailVerification struct {
+	Valid           bool  `json:"valid"`            // Valid is true if every entry of the audit trail is unchanged and chained to the previous one.
+	VerifiedEntries int64 `json:"verified_entries"` // VerifiedEntries is the number of the entries verified before the first broken one.
+	BrokenSeq       int64 `json:"broken_seq"`       // BrokenSeq is the position of the first entry that breaks the chain, it is 0 if the audit trail is valid.
+}
+
+// NewAuditTrailVerification creates a new *AuditTrailVerification.
+func NewAuditTrailVerification(valid bool,
+	verifiedEntries int64,
+	brokenSeq int64) *AuditTrailVerification {
+	return &AuditTrailVerification{
+		Valid:           valid,
+		VerifiedEntries: verifiedEntries,
+		BrokenSeq:       brokenSeq,
+	}
+}
+
+// String returns a string representation of the AuditTrailVerification.
+func (s *AuditTrailVerification) String() string {
+	return fmt.Sprintf("Valid: %v, "+
+		"VerifiedEntries: %v, "+
+		"BrokenSeq: %v",
+		s.Valid,
+		s.VerifiedEntries,
+		s.BrokenSeq)
+}
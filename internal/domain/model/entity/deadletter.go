@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeadLetter is a struct that represents the entity of an event bus message that could not be processed.
+type DeadLetter struct {
+	Id             string    `json:"id"`               // Id is the id of the message in the dead-letter stream.
+	ChannelName    string    `json:"channel_name"`     // ChannelName is the channel that the message was received from.
+	MessageId      string    `json:"message_id"`       // MessageId is the original id of the message in the channel.
+	Message        string    `json:"message"`          // Message is the original payload of the message.
+	Error          string    `json:"error"`            // Error is the error of the last processing attempt.
+	Attempts       int64     `json:"attempts"`         // Attempts is the number of the processing attempts.
+	ReceivedAt     time.Time `json:"received_at"`      // ReceivedAt is the time the message was added to the channel.
+	DeadLetteredAt time.Time `json:"dead_lettered_at"` // DeadLetteredAt is the time the message was moved to the dead-letter stream.
+}
+
+// NewDeadLetter creates a new *DeadLetter.
+func NewDeadLetter(id string,
+	channelName string,
+	messageId string,
+	message string,
+	errorText string,
+	attempts int64,
+	receivedAt time.Time,
+	deadLetteredAt time.Time) *DeadLetter {
+	return &DeadLetter{
+		Id:             id,
+		ChannelName:    channelName,
+		MessageId:      messageId,
+		Message:        message,
+		Error:          errorText,
+		Attempts:       attempts,
+		ReceivedAt:     receivedAt,
+		DeadLetteredAt: deadLetteredAt,
+	}
+}
+
+// String returns a string representation of the DeadLetter.
+func (s *DeadLetter) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"ChannelName: %v, "+
+		"MessageId: %v, "+
+		"Message: %v, "+
+		"Error: %v, "+
+		"Attempts: %v, "+
+		"ReceivedAt: %v, "+
+		"DeadLetteredAt: %v",
+		s.Id,
+		s.ChannelName,
+		s.MessageId,
+		s.Message,
+		s.Error,
+		s.Attempts,
+		s.ReceivedAt,
+		s.DeadLetteredAt)
+}
+
+// DeadLetters contains a slice of *DeadLetter and total number of records.
+type DeadLetters struct {
+	DeadLetters []DeadLetter `json:"dead_letters"` // DeadLetters is the slice of *DeadLetter.
+	TotalRows   int64        `json:"total_rows"`   // TotalRows is the total number of rows.
+}
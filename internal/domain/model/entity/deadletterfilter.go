@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// DeadLetterFilter is a struct that represents the filter of the dead-lettered messages of a channel.
+type DeadLetterFilter struct {
+	ChannelName string   `json:"channel_name"` // ChannelName is the channel of the messages.
+	Ids         []string `json:"ids"`          // Ids selects the given messages only.
+
+	AfterId string `json:"after_id"` // AfterId provides a starting message id of the limitation, exclusive.
+	Limit   int    `json:"limit"`    // Limit provides to limitation row size.
+}
+
+// NewDeadLetterFilter creates a new *DeadLetterFilter.
+func NewDeadLetterFilter(channelName string,
+	ids []string,
+	afterId string,
+	limit int) *DeadLetterFilter {
+	return &DeadLetterFilter{
+		ChannelName: channelName,
+		Ids:         ids,
+		AfterId:     afterId,
+		Limit:       limit,
+	}
+}
+
+// String returns a string representation of the DeadLetterFilter.
+func (s *DeadLetterFilter) String() string {
+	return fmt.Sprintf("ChannelName: %v, "+
+		"Ids: %v, "+
+		"AfterId: %v, "+
+		"Limit: %v",
+		s.ChannelName,
+		s.Ids,
+		s.AfterId,
+		s.Limit)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// IdempotentResult is a struct that represents the entity of the outcome of an idempotent request.
+type IdempotentResult struct {
+	Key         string `json:"key"`          // Key is the idempotency key of the request.
+	RequestHash string `json:"request_hash"` // RequestHash is the fingerprint of the payload of the request.
+	InProgress  bool   `json:"in_progress"`  // InProgress is true until the outcome of the request is known.
+	User        User   `json:"user"`         // User is the user returned by the request.
+	Error       string `json:"error"`        // Error is the error returned by the request.
+}
+
+// NewIdempotentResult creates a new *IdempotentResult.
+func NewIdempotentResult(key string,
+	requestHash string,
+	inProgress bool,
+	user User,
+	errorText string) *IdempotentResult {
+	return &IdempotentResult{
+		Key:         key,
+		RequestHash: requestHash,
+		InProgress:  inProgress,
+		User:        user,
+		Error:       errorText,
+	}
+}
+
+// String returns a string representation of the IdempotentResult.
+func (s *IdempotentResult) String() string {
+	return fmt.Sprintf("Key: %v, "+
+		"RequestHash: %v, "+
+		"InProgress: %v, "+
+		"User: %v, "+
+		"Error: %v",
+		s.Key,
+		s.RequestHash,
+		s.InProgress,
+		s.User,
+		s.Error)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NotificationData is a struct that represents the entity of a notification.
+type NotificationData struct {
+	Id                     uuid.UUID `json:"id"` // Id is the id of the notification.
+	*pb.NotificationHeader           // NotificationHeader is the header of the notification.
+	*pb.NotificationBody             // NotificationBody is the body of the notification.
+}
+
+// NewNotificationData creates a new *NotificationData.
+func NewNotificationData() *NotificationData {
+	return &NotificationData{
+		Id:                 uuid.UUID{},
+		NotificationHeader: &pb.NotificationHeader{},
+		NotificationBody:   &pb.NotificationBody{},
+	}
+}
+
+// GenerateNotificationData generated a new *pb.NotificationData
+func (s *NotificationData) GenerateNotificationData(notificationType pb.NotificationType,
+	userId string,
+	email string,
+	subject string,
+	message string) *pb.NotificationData {
+
+	return &pb.NotificationData{
+		Id: uuid.UUID{}.String(),
+		Header: &pb.NotificationHeader{
+			EventDate:        timestamppb.New(time.Now()),
+			NotificationType: notificationType,
+			ServiceName:      smodel.ServiceUser,
+			UserId:           userId,
+			Email:            email,
+		},
+		Body: &pb.NotificationBody{
+			Subject: subject,
+			Message: message,
+		},
+	}
+}
+
+// String returns a string representation of the NotificationData.
+func (s *NotificationData) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"NotificationHeader: %v, "+
+		"NotificationBody: %v",
+		s.Id,
+		s.NotificationHeader,
+		s.NotificationBody)
+}
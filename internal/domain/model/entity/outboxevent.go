@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a struct that represents the entity of a user event waiting in the outbox for delivery.
+type OutboxEvent struct {
+	Id            uuid.UUID `json:"id"`              // Id is the id of the outbox record.
+	UserEvent     UserEvent `json:"user_event"`      // UserEvent is the event to be delivered.
+	Attempts      int       `json:"attempts"`        // Attempts is the number of the failed delivery attempts.
+	NextAttemptAt time.Time `json:"next_attempt_at"` // NextAttemptAt is the earliest time of the next delivery attempt.
+	LastError     string    `json:"last_error"`      // LastError is the error of the last failed delivery attempt.
+	SentAt        time.Time `json:"sent_at"`         // SentAt is the delivery time, it is zero until the event is delivered.
+
+	// Only for view
+	CreatedAt time.Time `json:"created_at"` // CreatedAt is the time the event is written to the outbox.
+}
+
+// NewOutboxEvent creates a new *OutboxEvent.
+func NewOutboxEvent(id uuid.UUID,
+	userEvent UserEvent) *OutboxEvent {
+	return &OutboxEvent{
+		Id:        id,
+		UserEvent: userEvent,
+	}
+}
+
+// String returns a string representation of the OutboxEvent.
+func (s *OutboxEvent) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserEvent: %v, "+
+		"Attempts: %v, "+
+		"NextAttemptAt: %v, "+
+		"LastError: %v, "+
+		"SentAt: %v, "+
+		"CreatedAt: %v",
+		s.Id,
+		s.UserEvent,
+		s.Attempts,
+		s.NextAttemptAt,
+		s.LastError,
+		s.SentAt,
+		s.CreatedAt)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// RetentionPurge is a struct that represents the entity of a purge of the records of a dataset that are older than its retention period.
+type RetentionPurge struct {
+	RetentionDataset mo.RetentionDataset `json:"retention_dataset"` // RetentionDataset is the purged dataset.
+	Before           time.Time           `json:"before"`            // Before is the time the records older than are purged.
+	Count            int64               `json:"count"`             // Count is the number of the purged records, or the records to purge on a dry run.
+	DryRun           bool                `json:"dry_run"`           // DryRun is true if the records are only counted.
+}
+
+// NewRetentionPurge creates a new *RetentionPurge.
+func NewRetentionPurge(retentionDataset mo.RetentionDataset,
+	before time.Time,
+	count int64,
+	dryRun bool) *RetentionPurge {
+	return &RetentionPurge{
+		RetentionDataset: retentionDataset,
+		Before:           before,
+		Count:            count,
+		DryRun:           dryRun,
+	}
+}
+
+// String returns a string representation of the RetentionPurge.
+func (s *RetentionPurge) String() string {
+	return fmt.Sprintf("RetentionDataset: %v, "+
+		"Before: %v, "+
+		"Count: %v, "+
+		"DryRun: %v",
+		s.RetentionDataset,
+		s.Before,
+		s.Count,
+		s.DryRun)
+}
@@ -16,6 +16,14 @@ type User struct {
 	// Only for view
 	CreatedAt time.Time `json:"created_at"` // CreatedAt is the create time.
 	UpdatedAt time.Time `json:"updated_at"` // UpdatedAt is the update time.
+
+	LastLoginAt      time.Time `json:"last_login_at"`      // LastLoginAt is the time of the last login.
+	LastActivityAt   time.Time `json:"last_activity_at"`   // LastActivityAt is the time of the last activity.
+	DormancyWarnedAt time.Time `json:"dormancy_warned_at"` // DormancyWarnedAt is the time the user was warned about the dormancy of the account.
+
+	DeletedAt time.Time `json:"deleted_at"` // DeletedAt is the soft-delete time, it is zero if the user is not deleted.
+	DeletedBy uuid.UUID `json:"deleted_by"` // DeletedBy is the id of the user who deleted the user.
+	ErasedAt  time.Time `json:"erased_at"`  // ErasedAt is the erasure time, the user is only an anonymized tombstone after it.
 }
 
 // NewUser creates a new *User.
@@ -99,4 +107,6 @@ func (s *User) Validate() error {
 type Users struct {
 	Users     []User `json:"users"`      // Users is the slice of *User.
 	TotalRows int64  `json:"total_rows"` // TotalRows is the total number of rows.
+
+	NextPageToken string `json:"next_page_token"` // NextPageToken is the token of the next page, it is empty on the last page.
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserActivity is a struct that represents the entity of an activity of a user.
+type UserActivity struct {
+	UserId           uuid.UUID           `json:"user_id"`            // UserId is the id of the user.
+	UserActivityType mo.UserActivityType `json:"user_activity_type"` // UserActivityType is the type of the activity.
+	OccurredAt       time.Time           `json:"occurred_at"`        // OccurredAt is the time of the activity.
+}
+
+// NewUserActivity creates a new *UserActivity.
+func NewUserActivity(userId uuid.UUID,
+	userActivityType mo.UserActivityType,
+	occurredAt time.Time) *UserActivity {
+	return &UserActivity{
+		UserId:           userId,
+		UserActivityType: userActivityType,
+		OccurredAt:       occurredAt,
+	}
+}
+
+// String returns a string representation of the UserActivity.
+func (s *UserActivity) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"UserActivityType: %v, "+
+		"OccurredAt: %v",
+		s.UserId,
+		s.UserActivityType,
+		s.OccurredAt)
+}
+
+// Validate validates the UserActivity.
+func (s *UserActivity) Validate() error {
+	if s.UserId.String() == "" || s.UserId == (uuid.UUID{}) {
+		return mo.ErrorUserIdIsEmpty
+	}
+	if s.UserActivityType == mo.UserActivityTypeNONE {
+		return mo.ErrorUserActivityTypeIsEmpty
+	}
+	return nil
+}
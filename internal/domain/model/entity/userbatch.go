@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserBatch is a struct that represents the entity of a command applied to many users at once.
+// The users are given by their ids or by a filter, the other values are used by the command they belong to.
+type UserBatch struct {
+	UserIds       []uuid.UUID      `json:"user_ids"`        // UserIds are the ids of the users, they are used if they are given.
+	UserFilter    *UserFilter      `json:"user_filter"`     // UserFilter selects the users if no id is given.
+	UserBatchMode mo.UserBatchMode `json:"user_batch_mode"` // UserBatchMode is how the items are committed.
+
+	UserStatus     mo.UserStatus `json:"user_status"`     // UserStatus is the new status of the users.
+	Reason         string        `json:"reason"`          // Reason is the reason of the status transition.
+	SuspendedUntil time.Time     `json:"suspended_until"` // SuspendedUntil is the end of the suspension of the users.
+	Role           string        `json:"role"`            // Role is the new role of the users.
+	Tags           []string      `json:"tags"`            // Tags are the tags added to or removed from the users.
+}
+
+// NewUserBatch creates a new *UserBatch.
+func NewUserBatch(userIds []uuid.UUID,
+	userFilter *UserFilter,
+	userBatchMode mo.UserBatchMode) *UserBatch {
+	return &UserBatch{
+		UserIds:       userIds,
+		UserFilter:    userFilter,
+		UserBatchMode: userBatchMode,
+	}
+}
+
+// String returns a string representation of the UserBatch.
+func (s *UserBatch) String() string {
+	return fmt.Sprintf("UserIds: %v, "+
+		"UserFilter: %v, "+
+		"UserBatchMode: %v, "+
+		"UserStatus: %v, "+
+		"Reason: %v, "+
+		"SuspendedUntil: %v, "+
+		"Role: %v, "+
+		"Tags: %v",
+		len(s.UserIds),
+		s.UserFilter,
+		s.UserBatchMode,
+		s.UserStatus,
+		s.Reason,
+		s.SuspendedUntil,
+		s.Role,
+		s.Tags)
+}
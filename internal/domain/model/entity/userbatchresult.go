@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UserBatchResult is a struct that represents the entity of the outcome of an item of a user batch command.
+type UserBatchResult struct {
+	UserId uuid.UUID `json:"user_id"` // UserId is the id of the user of the item.
+	User   User      `json:"user"`    // User is the user after the command, it is empty if the item failed.
+	Error  string    `json:"error"`   // Error is the reason the item failed, it is empty if the item succeeded.
+}
+
+// NewUserBatchResult creates a new *UserBatchResult.
+func NewUserBatchResult(userId uuid.UUID,
+	user User,
+	errorText string) *UserBatchResult {
+	return &UserBatchResult{
+		UserId: userId,
+		User:   user,
+		Error:  errorText,
+	}
+}
+
+// String returns a string representation of the UserBatchResult.
+func (s *UserBatchResult) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"User: %v, "+
+		"Error: %v",
+		s.UserId,
+		s.User,
+		s.Error)
+}
+
+// UserBatchResults contains a slice of *UserBatchResult and the number of the succeeded and the failed items.
+type UserBatchResults struct {
+	UserBatchResults []UserBatchResult `json:"user_batch_results"` // UserBatchResults is the slice of *UserBatchResult.
+	Succeeded        int64             `json:"succeeded"`          // Succeeded is the number of the applied items.
+	Failed           int64             `json:"failed"`             // Failed is the number of the failed items.
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UserCommand is a struct that represents the entity of a user command received from the event bus.
+type UserCommand struct {
+	Id               string           `json:"id"`                 // Id is the id of the command message.
+	ChannelName      string           `json:"channel_name"`       // ChannelName is the channel the command is received from.
+	User             User             `json:"user"`               // User is the user of the create, update, role and delete commands.
+	UserStatusChange UserStatusChange `json:"user_status_change"` // UserStatusChange is the transition of the status command.
+	UserPassword     UserPassword     `json:"user_password"`      // UserPassword is the new password of the password reset command.
+}
+
+// NewUserCommand creates a new *UserCommand.
+func NewUserCommand(id string,
+	channelName string,
+	user User,
+	userStatusChange UserStatusChange,
+	userPassword UserPassword) *UserCommand {
+	return &UserCommand{
+		Id:               id,
+		ChannelName:      channelName,
+		User:             user,
+		UserStatusChange: userStatusChange,
+		UserPassword:     userPassword,
+	}
+}
+
+// String returns a string representation of the UserCommand.
+func (s *UserCommand) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"ChannelName: %v, "+
+		"User: %v, "+
+		"UserStatusChange: %v, "+
+		"UserPassword: ***",
+		s.Id,
+		s.ChannelName,
+		s.User,
+		s.UserStatusChange)
+}
+
+// UserId returns the id of the user the command is about.
+func (s *UserCommand) UserId() uuid.UUID {
+	if s.UserStatusChange.UserId != (uuid.UUID{}) {
+		return s.UserStatusChange.UserId
+	}
+	if s.UserPassword.UserId != (uuid.UUID{}) {
+		return s.UserPassword.UserId
+	}
+	return s.User.Id
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserCommandResult is a struct that represents the entity of the outcome of a user command received from the event bus.
+type UserCommandResult struct {
+	CommandId   string    `json:"command_id"`   // CommandId is the id of the command message.
+	ChannelName string    `json:"channel_name"` // ChannelName is the channel the command is received from.
+	UserId      uuid.UUID `json:"user_id"`      // UserId is the id of the user the command is about.
+	Success     bool      `json:"success"`      // Success is true if the command is applied.
+	Error       string    `json:"error"`        // Error is the reason of the rejection of the command.
+	User        User      `json:"user"`         // User is the user after the command.
+	OccurredAt  time.Time `json:"occurred_at"`  // OccurredAt is the time the command is handled.
+}
+
+// NewUserCommandResult creates a new *UserCommandResult.
+func NewUserCommandResult(commandId string,
+	channelName string,
+	userId uuid.UUID,
+	success bool,
+	errorText string,
+	user User,
+	occurredAt time.Time) *UserCommandResult {
+	return &UserCommandResult{
+		CommandId:   commandId,
+		ChannelName: channelName,
+		UserId:      userId,
+		Success:     success,
+		Error:       errorText,
+		User:        user,
+		OccurredAt:  occurredAt,
+	}
+}
+
+// String returns a string representation of the UserCommandResult.
+func (s *UserCommandResult) String() string {
+	return fmt.Sprintf("CommandId: %v, "+
+		"ChannelName: %v, "+
+		"UserId: %v, "+
+		"Success: %v, "+
+		"Error: %v, "+
+		"User: %v, "+
+		"OccurredAt: %v",
+		s.CommandId,
+		s.ChannelName,
+		s.UserId,
+		s.Success,
+		s.Error,
+		s.User,
+		s.OccurredAt)
+}
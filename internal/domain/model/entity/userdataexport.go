@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// UserDataExport is a struct that represents the entity of everything the service holds about a user.
+// The role of the user is kept in the user itself, the groups and the sessions are not held by this service.
+type UserDataExport struct {
+	User                User                `json:"user"`                  // User is the profile of the user.
+	UserStatusHistories []UserStatusHistory `json:"user_status_histories"` // UserStatusHistories are the status transitions of the user.
+	UserLoginHistories  []UserLoginHistory  `json:"user_login_histories"`  // UserLoginHistories are the authentication attempts of the user.
+	AuditEntries        []AuditEntry        `json:"audit_entries"`         // AuditEntries are the audit trail entries of the commands run on the user.
+	UserReadAccesses    []UserReadAccess    `json:"user_read_accesses"`    // UserReadAccesses are the reads of the personal data of the user.
+	ExportedAt          time.Time           `json:"exported_at"`           // ExportedAt is the time of the export.
+}
+
+// NewUserDataExport creates a new *UserDataExport.
+func NewUserDataExport(user User,
+	userStatusHistories []UserStatusHistory,
+	userLoginHistories []UserLoginHistory,
+	auditEntries []AuditEntry,
+	userReadAccesses []UserReadAccess,
+	exportedAt time.Time) *UserDataExport {
+	return &UserDataExport{
+		User:                user,
+		UserStatusHistories: userStatusHistories,
+		UserLoginHistories:  userLoginHistories,
+		AuditEntries:        auditEntries,
+		UserReadAccesses:    userReadAccesses,
+		ExportedAt:          exportedAt,
+	}
+}
+
+// String returns a string representation of the UserDataExport.
+func (s *UserDataExport) String() string {
+	return fmt.Sprintf("User: %v, "+
+		"UserStatusHistories: %v, "+
+		"UserLoginHistories: %v, "+
+		"AuditEntries: %v, "+
+		"UserReadAccesses: %v, "+
+		"ExportedAt: %v",
+		s.User,
+		len(s.UserStatusHistories),
+		len(s.UserLoginHistories),
+		len(s.AuditEntries),
+		len(s.UserReadAccesses),
+		s.ExportedAt)
+}
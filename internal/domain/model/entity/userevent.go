@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserEvent is a struct that represents the entity of a domain event of a user mutation.
+type UserEvent struct {
+	Id            uuid.UUID        `json:"id"`              // Id is the id of the event.
+	UserEventType mo.UserEventType `json:"user_event_type"` // UserEventType is the type of the event.
+	Before        User             `json:"before"`          // Before is the state of the user before the mutation.
+	After         User             `json:"after"`           // After is the state of the user after the mutation.
+	ActorId       uuid.UUID        `json:"actor_id"`        // ActorId is the id of the user who applied the mutation.
+	OccurredAt    time.Time        `json:"occurred_at"`     // OccurredAt is the time of the mutation.
+	CorrelationId string           `json:"correlation_id"`  // CorrelationId is the id of the request that caused the mutation.
+}
+
+// NewUserEvent creates a new *UserEvent.
+func NewUserEvent(id uuid.UUID,
+	userEventType mo.UserEventType,
+	before User,
+	after User,
+	actorId uuid.UUID,
+	occurredAt time.Time,
+	correlationId string) *UserEvent {
+	return &UserEvent{
+		Id:            id,
+		UserEventType: userEventType,
+		Before:        before,
+		After:         after,
+		ActorId:       actorId,
+		OccurredAt:    occurredAt,
+		CorrelationId: correlationId,
+	}
+}
+
+// String returns a string representation of the UserEvent.
+func (s *UserEvent) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserEventType: %v, "+
+		"Before: %v, "+
+		"After: %v, "+
+		"ActorId: %v, "+
+		"OccurredAt: %v, "+
+		"CorrelationId: %v",
+		s.Id,
+		s.UserEventType,
+		s.Before,
+		s.After,
+		s.ActorId,
+		s.OccurredAt,
+		s.CorrelationId)
+}
@@ -11,6 +11,7 @@ import (
 // UserFilter is a struct that represents the filter of a user.
 type UserFilter struct {
 	Id         uuid.UUID     `json:"id"`          // Id is the id of the user.
+	Ids        []uuid.UUID   `json:"ids"`         // Ids are the ids of the users, any of them matches.
 	UserName   string        `json:"user_name"`   // UserName is the user name of the user.
 	Email      string        `json:"email"`       // Email is the email address of the user.
 	UserType   mo.UserType   `json:"user_type"`   // UserType is the type of the user.
@@ -24,12 +25,23 @@ type UserFilter struct {
 	UpdatedAtFrom time.Time `json:"updated_at_from"` // UpdatedAt is in the between of UpdatedAtFrom and UpdatedAtTo.
 	UpdatedAtTo   time.Time `json:"updated_at_to"`   // UpdatedAt is in the between of UpdatedAtFrom and UpdatedAtTo.
 
+	SuspendedUntilBefore time.Time `json:"suspended_until_before"` // SuspendedUntil is set and not after SuspendedUntilBefore.
+	ExpiresAtBefore      time.Time `json:"expires_at_before"`      // ExpiresAt is set and not after ExpiresAtBefore.
+	LastLoginBefore      time.Time `json:"last_login_before"`      // LastLoginAt is before LastLoginBefore or the user has never logged in.
+	LastLoginAfter       time.Time `json:"last_login_after"`       // LastLoginAt is after LastLoginAfter.
+
+	IncludeDeleted bool `json:"include_deleted"` // IncludeDeleted returns the soft-deleted users too.
+	OnlyDeleted    bool `json:"only_deleted"`    // OnlyDeleted returns only the soft-deleted users.
+
 	SearchText string           `json:"search_text"` // SearchText is the full-text search value.
 	SortType   string           `json:"sort_type"`   // SortType is the sorting type (ASC,DESC).
 	SortField  mo.UserSortField `json:"sort_field"`  // SortField is the sorting field of the user.
 
 	Limit  int `json:"limit"`  // Limit provides to limitation row size.
 	Offset int `json:"offset"` // Offset provides a starting row number of the limitation.
+
+	PageToken string           `json:"page_token"` // PageToken is the token of the next page returned by the previous page, it is used instead of the offset.
+	CountMode mo.UserCountMode `json:"count_mode"` // CountMode is how the total number of rows is counted.
 }
 
 // NewUserFilter creates a new *UserFilter.
@@ -45,6 +57,10 @@ func NewUserFilter(id uuid.UUID,
 	createdAtTo time.Time,
 	updatedAtFrom time.Time,
 	updatedAtTo time.Time,
+	suspendedUntilBefore time.Time,
+	expiresAtBefore time.Time,
+	lastLoginBefore time.Time,
+	lastLoginAfter time.Time,
 	searchText string,
 	sortType string,
 	sortField mo.UserSortField,
@@ -63,11 +79,17 @@ func NewUserFilter(id uuid.UUID,
 		CreatedAtTo:   createdAtTo,
 		UpdatedAtFrom: updatedAtFrom,
 		UpdatedAtTo:   updatedAtTo,
-		SearchText:    searchText,
-		SortType:      sortType,
-		SortField:     sortField,
-		Limit:         limit,
-		Offset:        offset,
+
+		SuspendedUntilBefore: suspendedUntilBefore,
+		ExpiresAtBefore:      expiresAtBefore,
+		LastLoginBefore:      lastLoginBefore,
+		LastLoginAfter:       lastLoginAfter,
+
+		SearchText: searchText,
+		SortType:   sortType,
+		SortField:  sortField,
+		Limit:      limit,
+		Offset:     offset,
 	}
 }
 
@@ -86,11 +108,17 @@ func NewEmptyUserFilter() *UserFilter {
 		CreatedAtTo:   time.Time{},
 		UpdatedAtFrom: time.Time{},
 		UpdatedAtTo:   time.Time{},
-		SearchText:    "",
-		SortType:      "",
-		SortField:     mo.UserSortFieldNONE,
-		Limit:         0,
-		Offset:        0,
+
+		SuspendedUntilBefore: time.Time{},
+		ExpiresAtBefore:      time.Time{},
+		LastLoginBefore:      time.Time{},
+		LastLoginAfter:       time.Time{},
+
+		SearchText: "",
+		SortType:   "",
+		SortField:  mo.UserSortFieldNONE,
+		Limit:      0,
+		Offset:     0,
 	}
 }
 
@@ -108,6 +136,12 @@ func (s *UserFilter) String() string {
 		"CreatedAtTo: %v, "+
 		"UpdatedAtFrom: %v, "+
 		"UpdatedAtTo: %v, "+
+		"SuspendedUntilBefore: %v, "+
+		"ExpiresAtBefore: %v, "+
+		"LastLoginBefore: %v, "+
+		"LastLoginAfter: %v, "+
+		"IncludeDeleted: %v, "+
+		"OnlyDeleted: %v, "+
 		"SearchText: %v, "+
 		"SortType: %v, "+
 		"SortField: %v, "+
@@ -125,6 +159,12 @@ func (s *UserFilter) String() string {
 		s.CreatedAtTo,
 		s.UpdatedAtFrom,
 		s.UpdatedAtTo,
+		s.SuspendedUntilBefore,
+		s.ExpiresAtBefore,
+		s.LastLoginBefore,
+		s.LastLoginAfter,
+		s.IncludeDeleted,
+		s.OnlyDeleted,
 		s.SearchText,
 		s.SortType,
 		s.SortField,
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserImportRow is a struct that represents the entity of a row of a user import.
+type UserImportRow struct {
+	Line     int    `json:"line"`     // Line is the line of the row in the imported file.
+	User     User   `json:"user"`     // User is the user to be created.
+	Password string `json:"password"` // Password is the optional initial password of the user.
+	Invite   bool   `json:"invite"`   // Invite sends an invitation to the user, the user is pending if its status is not given.
+}
+
+// NewUserImportRow creates a new *UserImportRow.
+func NewUserImportRow(line int,
+	user User,
+	password string,
+	invite bool) *UserImportRow {
+	return &UserImportRow{
+		Line:     line,
+		User:     user,
+		Password: password,
+		Invite:   invite,
+	}
+}
+
+// String returns a string representation of the UserImportRow.
+func (s *UserImportRow) String() string {
+	return fmt.Sprintf("Line: %v, "+
+		"User: %v, "+
+		"Password: ***, "+
+		"Invite: %v",
+		s.Line,
+		s.User,
+		s.Invite)
+}
+
+// UserImport is a struct that represents the entity of the options of a bulk creation of users, the rows are read separately.
+type UserImport struct {
+	UserImportMode mo.UserImportMode `json:"user_import_mode"` // UserImportMode is how the rows are committed.
+	DryRun         bool              `json:"dry_run"`          // DryRun only checks the rows without creating the users.
+}
+
+// NewUserImport creates a new *UserImport.
+func NewUserImport(userImportMode mo.UserImportMode,
+	dryRun bool) *UserImport {
+	return &UserImport{
+		UserImportMode: userImportMode,
+		DryRun:         dryRun,
+	}
+}
+
+// String returns a string representation of the UserImport.
+func (s *UserImport) String() string {
+	return fmt.Sprintf("UserImportMode: %v, "+
+		"DryRun: %v",
+		s.UserImportMode,
+		s.DryRun)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UserImportResult is a struct that represents the entity of the outcome of a row of a user import.
+type UserImportResult struct {
+	Line    int       `json:"line"`    // Line is the line of the row in the imported file.
+	UserId  uuid.UUID `json:"user_id"` // UserId is the id of the created user, it is empty if the user is not created.
+	Error   string    `json:"error"`   // Error is the reason the row is rejected, it is empty if the row is valid.
+	Invited bool      `json:"invited"` // Invited is true if the invitation is sent to the user.
+}
+
+// NewUserImportResult creates a new *UserImportResult.
+func NewUserImportResult(line int,
+	userId uuid.UUID,
+	errorText string,
+	invited bool) *UserImportResult {
+	return &UserImportResult{
+		Line:    line,
+		UserId:  userId,
+		Error:   errorText,
+		Invited: invited,
+	}
+}
+
+// String returns a string representation of the UserImportResult.
+func (s *UserImportResult) String() string {
+	return fmt.Sprintf("Line: %v, "+
+		"UserId: %v, "+
+		"Error: %v, "+
+		"Invited: %v",
+		s.Line,
+		s.UserId,
+		s.Error,
+		s.Invited)
+}
+
+// UserImportResults contains a slice of *UserImportResult and the number of the imported and the failed rows.
+type UserImportResults struct {
+	UserImportResults []UserImportResult `json:"user_import_results"` // UserImportResults is the slice of *UserImportResult.
+	Imported          int64              `json:"imported"`            // Imported is the number of the created users.
+	Failed            int64              `json:"failed"`              // Failed is the number of the rejected rows.
+	DryRun            bool               `json:"dry_run"`             // DryRun is true if the rows are only checked.
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserLoginHistory is a struct that represents the entity of an authentication attempt of a user.
+type UserLoginHistory struct {
+	Id        uuid.UUID `json:"id"`         // Id is the id of the history record.
+	UserId    uuid.UUID `json:"user_id"`    // UserId is the id of the user.
+	Success   bool      `json:"success"`    // Success is true if the authentication attempt succeeded.
+	Reason    string    `json:"reason"`     // Reason is the failure reason of the authentication attempt.
+	IpAddress string    `json:"ip_address"` // IpAddress is the ip address that the attempt comes from.
+	UserAgent string    `json:"user_agent"` // UserAgent is the user agent of the attempt.
+	Client    string    `json:"client"`     // Client is the client application of the attempt.
+
+	// Only for view
+	CreatedAt time.Time `json:"created_at"` // CreatedAt is the time of the attempt.
+}
+
+// NewUserLoginHistory creates a new *UserLoginHistory.
+func NewUserLoginHistory(id uuid.UUID,
+	userId uuid.UUID,
+	success bool,
+	reason string,
+	ipAddress string,
+	userAgent string,
+	client string) *UserLoginHistory {
+	return &UserLoginHistory{
+		Id:        id,
+		UserId:    userId,
+		Success:   success,
+		Reason:    reason,
+		IpAddress: ipAddress,
+		UserAgent: userAgent,
+		Client:    client,
+	}
+}
+
+// String returns a string representation of the UserLoginHistory.
+func (s *UserLoginHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"Success: %v, "+
+		"Reason: %v, "+
+		"IpAddress: %v, "+
+		"UserAgent: %v, "+
+		"Client: %v, "+
+		"CreatedAt: %v",
+		s.Id,
+		s.UserId,
+		s.Success,
+		s.Reason,
+		s.IpAddress,
+		s.UserAgent,
+		s.Client,
+		s.CreatedAt)
+}
+
+// Validate validates the UserLoginHistory.
+func (s *UserLoginHistory) Validate() error {
+	if s.UserId.String() == "" || s.UserId == (uuid.UUID{}) {
+		return mo.ErrorUserIdIsEmpty
+	}
+	return nil
+}
+
+// UserLoginHistories contains a slice of *UserLoginHistory and total number of records.
+type UserLoginHistories struct {
+	UserLoginHistories []UserLoginHistory `json:"user_login_histories"` // UserLoginHistories is the slice of *UserLoginHistory.
+	TotalRows          int64              `json:"total_rows"`           // TotalRows is the total number of rows.
+}
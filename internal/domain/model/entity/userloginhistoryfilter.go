@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserLoginHistoryFilter is a struct that represents the filter of the login history of a user.
+type UserLoginHistoryFilter struct {
+	UserId uuid.UUID `json:"user_id"` // UserId is the id of the user.
+
+	CreatedAtFrom time.Time `json:"created_at_from"` // CreatedAt is in the between of CreatedAtFrom and CreatedAtTo.
+	CreatedAtTo   time.Time `json:"created_at_to"`   // CreatedAt is in the between of CreatedAtFrom and CreatedAtTo.
+
+	Limit  int `json:"limit"`  // Limit provides to limitation row size.
+	Offset int `json:"offset"` // Offset provides a starting row number of the limitation.
+}
+
+// NewUserLoginHistoryFilter creates a new *UserLoginHistoryFilter.
+func NewUserLoginHistoryFilter(userId uuid.UUID,
+	createdAtFrom time.Time,
+	createdAtTo time.Time,
+	limit int,
+	offset int) *UserLoginHistoryFilter {
+	return &UserLoginHistoryFilter{
+		UserId:        userId,
+		CreatedAtFrom: createdAtFrom,
+		CreatedAtTo:   createdAtTo,
+		Limit:         limit,
+		Offset:        offset,
+	}
+}
+
+// String returns a string representation of the UserLoginHistoryFilter.
+func (s *UserLoginHistoryFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"CreatedAtFrom: %v, "+
+		"CreatedAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.UserId,
+		s.CreatedAtFrom,
+		s.CreatedAtTo,
+		s.Limit,
+		s.Offset)
+}
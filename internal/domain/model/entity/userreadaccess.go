@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserReadAccess is a struct that represents the entity of a read of the personal data of users.
+type UserReadAccess struct {
+	Id        uuid.UUID   `json:"id"`         // Id is the id of the read record.
+	ActorId   uuid.UUID   `json:"actor_id"`   // ActorId is the id of the user who read the data.
+	Operation string      `json:"operation"`  // Operation is the name of the query that returned the data.
+	Filter    string      `json:"filter"`     // Filter is the JSON of the filter used by the query.
+	UserIds   []uuid.UUID `json:"user_ids"`   // UserIds are the ids of the returned users.
+	RequestId string      `json:"request_id"` // RequestId is the id of the request that ran the query.
+	ReadAt    time.Time   `json:"read_at"`    // ReadAt is the time of the read.
+}
+
+// NewUserReadAccess creates a new *UserReadAccess.
+func NewUserReadAccess(id uuid.UUID,
+	actorId uuid.UUID,
+	operation string,
+	filter string,
+	userIds []uuid.UUID,
+	requestId string,
+	readAt time.Time) *UserReadAccess {
+	return &UserReadAccess{
+		Id:        id,
+		ActorId:   actorId,
+		Operation: operation,
+		Filter:    filter,
+		UserIds:   userIds,
+		RequestId: requestId,
+		ReadAt:    readAt,
+	}
+}
+
+// String returns a string representation of the UserReadAccess.
+func (s *UserReadAccess) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"ActorId: %v, "+
+		"Operation: %v, "+
+		"Filter: %v, "+
+		"UserIds: %v, "+
+		"RequestId: %v, "+
+		"ReadAt: %v",
+		s.Id,
+		s.ActorId,
+		s.Operation,
+		s.Filter,
+		s.UserIds,
+		s.RequestId,
+		s.ReadAt)
+}
+
+// UserReadAccesses contains a slice of *UserReadAccess and total number of records.
+type UserReadAccesses struct {
+	UserReadAccesses []UserReadAccess `json:"user_read_accesses"` // UserReadAccesses is the slice of *UserReadAccess.
+	TotalRows        int64            `json:"total_rows"`         // TotalRows is the total number of rows.
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserReadAccessFilter is a struct that represents the filter of the reads of the personal data of a user.
+type UserReadAccessFilter struct {
+	UserId  uuid.UUID `json:"user_id"`  // UserId is the id of the user whose data is read.
+	ActorId uuid.UUID `json:"actor_id"` // ActorId is the id of the user who read the data.
+
+	ReadAtFrom time.Time `json:"read_at_from"` // ReadAt is in the between of ReadAtFrom and ReadAtTo.
+	ReadAtTo   time.Time `json:"read_at_to"`   // ReadAt is in the between of ReadAtFrom and ReadAtTo.
+
+	Limit  int `json:"limit"`  // Limit provides to limitation row size.
+	Offset int `json:"offset"` // Offset provides a starting row number of the limitation.
+}
+
+// NewUserReadAccessFilter creates a new *UserReadAccessFilter.
+func NewUserReadAccessFilter(userId uuid.UUID,
+	actorId uuid.UUID,
+	readAtFrom time.Time,
+	readAtTo time.Time,
+	limit int,
+	offset int) *UserReadAccessFilter {
+	return &UserReadAccessFilter{
+		UserId:     userId,
+		ActorId:    actorId,
+		ReadAtFrom: readAtFrom,
+		ReadAtTo:   readAtTo,
+		Limit:      limit,
+		Offset:     offset,
+	}
+}
+
+// String returns a string representation of the UserReadAccessFilter.
+func (s *UserReadAccessFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"ActorId: %v, "+
+		"ReadAtFrom: %v, "+
+		"ReadAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.UserId,
+		s.ActorId,
+		s.ReadAtFrom,
+		s.ReadAtTo,
+		s.Limit,
+		s.Offset)
+}
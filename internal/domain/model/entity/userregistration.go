@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserRegistration is a struct that represents the entity of a self-registration request.
+type UserRegistration struct {
+	User     User   `json:"user"`      // User is the user to be registered.
+	Password string `json:"password"`  // Password is the initial password of the user.
+	ClientIp string `json:"client_ip"` // ClientIp is the ip address that the registration request comes from.
+}
+
+// NewUserRegistration creates a new *UserRegistration.
+func NewUserRegistration(user User,
+	password string,
+	clientIp string) *UserRegistration {
+	return &UserRegistration{
+		User:     user,
+		Password: password,
+		ClientIp: clientIp,
+	}
+}
+
+// NewEmptyUserRegistration creates a new *UserRegistration with empty values.
+func NewEmptyUserRegistration() *UserRegistration {
+	return &UserRegistration{
+		User:     *NewEmptyUser(),
+		Password: "",
+		ClientIp: "",
+	}
+}
+
+// String returns a string representation of the UserRegistration.
+func (s *UserRegistration) String() string {
+	return fmt.Sprintf("User: %v, "+
+		"Password: ***, "+
+		"ClientIp: %v",
+		s.User,
+		s.ClientIp)
+}
+
+// Validate validates the UserRegistration.
+func (s *UserRegistration) Validate() error {
+	if s.User.IsEmpty() {
+		return mo.ErrorUserIsEmpty
+	}
+	if s.Password == "" {
+		return mo.ErrorUserPasswordIsEmpty
+	}
+	return nil
+}
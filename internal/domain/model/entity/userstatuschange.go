@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserStatusChange is a struct that represents the entity of a requested user status transition.
+type UserStatusChange struct {
+	UserId     uuid.UUID     `json:"user_id"`     // UserId is the id of the user.
+	UserStatus mo.UserStatus `json:"user_status"` // UserStatus is the target status of the user.
+	Reason     string        `json:"reason"`      // Reason is the reason of the transition.
+
+	SuspendedUntil time.Time `json:"suspended_until"` // SuspendedUntil is the end of the suspension, only for the SUSPENDED status.
+}
+
+// NewUserStatusChange creates a new *UserStatusChange.
+func NewUserStatusChange(userId uuid.UUID,
+	userStatus mo.UserStatus,
+	reason string,
+	suspendedUntil time.Time) *UserStatusChange {
+	return &UserStatusChange{
+		UserId:         userId,
+		UserStatus:     userStatus,
+		Reason:         reason,
+		SuspendedUntil: suspendedUntil,
+	}
+}
+
+// NewEmptyUserStatusChange creates a new *UserStatusChange with empty values.
+func NewEmptyUserStatusChange() *UserStatusChange {
+	return &UserStatusChange{
+		UserId:         uuid.UUID{},
+		UserStatus:     mo.UserStatusNONE,
+		Reason:         "",
+		SuspendedUntil: time.Time{},
+	}
+}
+
+// String returns a string representation of the UserStatusChange.
+func (s *UserStatusChange) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"UserStatus: %v, "+
+		"Reason: %v, "+
+		"SuspendedUntil: %v",
+		s.UserId,
+		s.UserStatus,
+		s.Reason,
+		s.SuspendedUntil)
+}
+
+// Validate validates the UserStatusChange.
+func (s *UserStatusChange) Validate() error {
+	if s.UserId.String() == "" || s.UserId == (uuid.UUID{}) {
+		return mo.ErrorUserIdIsEmpty
+	}
+	if s.UserStatus == mo.UserStatusNONE {
+		return mo.ErrorUserStatusIsEmpty
+	}
+	// A user can only be deleted by DeleteUser, it also clears the credentials of the user.
+	if s.UserStatus == mo.UserStatusDELETED {
+		return mo.ErrorUserStatusDeletedIsNotAllowed
+	}
+	if !s.SuspendedUntil.IsZero() && s.UserStatus != mo.UserStatusSUSPENDED {
+		return mo.ErrorUserSuspendedUntilIsNotValid
+	}
+	return nil
+}
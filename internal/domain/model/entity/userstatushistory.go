@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserStatusHistory is a struct that represents the entity of an applied user status transition.
+type UserStatusHistory struct {
+	Id         uuid.UUID     `json:"id"`          // Id is the id of the history record.
+	UserId     uuid.UUID     `json:"user_id"`     // UserId is the id of the user.
+	FromStatus mo.UserStatus `json:"from_status"` // FromStatus is the status of the user before the transition.
+	ToStatus   mo.UserStatus `json:"to_status"`   // ToStatus is the status of the user after the transition.
+	Reason     string        `json:"reason"`      // Reason is the reason of the transition.
+	ActorId    uuid.UUID     `json:"actor_id"`    // ActorId is the id of the user who applied the transition.
+
+	// Only for view
+	CreatedAt time.Time `json:"created_at"` // CreatedAt is the time of the transition.
+}
+
+// NewUserStatusHistory creates a new *UserStatusHistory.
+func NewUserStatusHistory(id uuid.UUID,
+	userId uuid.UUID,
+	fromStatus mo.UserStatus,
+	toStatus mo.UserStatus,
+	reason string,
+	actorId uuid.UUID) *UserStatusHistory {
+	return &UserStatusHistory{
+		Id:         id,
+		UserId:     userId,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Reason:     reason,
+		ActorId:    actorId,
+	}
+}
+
+// String returns a string representation of the UserStatusHistory.
+func (s *UserStatusHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"FromStatus: %v, "+
+		"ToStatus: %v, "+
+		"Reason: %v, "+
+		"ActorId: %v, "+
+		"CreatedAt: %v",
+		s.Id,
+		s.UserId,
+		s.FromStatus,
+		s.ToStatus,
+		s.Reason,
+		s.ActorId,
+		s.CreatedAt)
+}
+
+// UserStatusHistories contains a slice of *UserStatusHistory and total number of records.
+type UserStatusHistories struct {
+	UserStatusHistories []UserStatusHistory `json:"user_status_histories"` // UserStatusHistories is the slice of *UserStatusHistory.
+	TotalRows           int64               `json:"total_rows"`            // TotalRows is the total number of rows.
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UserStatusHistoryFilter is a struct that represents the filter of the status history of a user.
+type UserStatusHistoryFilter struct {
+	UserId uuid.UUID `json:"user_id"` // UserId is the id of the user.
+
+	Limit  int `json:"limit"`  // Limit provides to limitation row size.
+	Offset int `json:"offset"` // Offset provides a starting row number of the limitation.
+}
+
+// NewUserStatusHistoryFilter creates a new *UserStatusHistoryFilter.
+func NewUserStatusHistoryFilter(userId uuid.UUID,
+	limit int,
+	offset int) *UserStatusHistoryFilter {
+	return &UserStatusHistoryFilter{
+		UserId: userId,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
+
+// String returns a string representation of the UserStatusHistoryFilter.
+func (s *UserStatusHistoryFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.UserId,
+		s.Limit,
+		s.Offset)
+}
@@ -0,0 +1,30 @@
+package domain
+
+// AuditAction is a type that represents the action of an audit trail entry.
+type AuditAction int8
+
+const (
+	AuditActionNONE AuditAction = iota
+	AuditActionCREATE
+	AuditActionUPDATE
+	AuditActionSTATUSCHANGE
+	AuditActionROLECHANGE
+	AuditActionPASSWORDCHANGE
+	AuditActionDELETE
+	AuditActionDEADLETTERREPLAY
+	AuditActionDEADLETTERPURGE
+	AuditActionRESTORE
+	AuditActionERASE
+)
+
+// UserEventAuditActions maps the user domain events to the audit trail actions.
+var UserEventAuditActions map[UserEventType]AuditAction = map[UserEventType]AuditAction{
+	UserEventTypeCREATED:         AuditActionCREATE,
+	UserEventTypeUPDATED:         AuditActionUPDATE,
+	UserEventTypeSTATUSCHANGED:   AuditActionSTATUSCHANGE,
+	UserEventTypeROLECHANGED:     AuditActionROLECHANGE,
+	UserEventTypePASSWORDCHANGED: AuditActionPASSWORDCHANGE,
+	UserEventTypeDELETED:         AuditActionDELETE,
+	UserEventTypeRESTORED:        AuditActionRESTORE,
+	UserEventTypeERASED:          AuditActionERASE,
+}
@@ -0,0 +1,10 @@
+package domain
+
+// These are the event bus channels of the user commands and queries besides the ones of the shared model.
+const (
+	ChannelUpdateUser        string = "UpdateUser"        // ChannelUpdateUser is the channel of the base value updates of the users.
+	ChannelChangeUserStatus  string = "ChangeUserStatus"  // ChannelChangeUserStatus is the channel of the status transitions of the users.
+	ChannelChangeUserRole    string = "ChangeUserRole"    // ChannelChangeUserRole is the channel of the role changes of the users.
+	ChannelResetUserPassword string = "ResetUserPassword" // ChannelResetUserPassword is the channel of the password resets of the users by the admins.
+	ChannelGetUsers          string = "GetUsers"          // ChannelGetUsers is the channel of the user filter queries.
+)
@@ -31,6 +31,40 @@ var ERRORS []error = []error{
 	ErrorUserPasswordIsNotValid,
 	ErrorUserPasswordNotFound,
 	ErrorUserPasswordIsInactive,
+	ErrorUserRegistrationIsDisabled,
+	ErrorUserRegistrationRateLimitExceeded,
+	ErrorUserEmailDomainIsNotAllowed,
+	ErrorUserEmailDomainIsDisposable,
+	ErrorUserIsPending,
+	ErrorUserIsSuspended,
+	ErrorUserIsLocked,
+	ErrorUserIsDeleted,
+	ErrorUserStatusIsEmpty,
+	ErrorUserStatusIsNotChanged,
+	ErrorUserStatusDeletedIsNotAllowed,
+	ErrorUserStatusTransitionIsNotValid,
+	ErrorUserStatusReasonIsEmpty,
+	ErrorUserIsExpired,
+	ErrorUserSuspendedUntilIsNotValid,
+	ErrorUserActivityTypeIsEmpty,
+	ErrorDeadLetterNotFound,
+	ErrorDeadLetterChannelIsNotValid,
+	ErrorDeadLetterChannelIsNotAllowed,
+	ErrorUserRequestIsInProgress,
+	ErrorUserRequestIsConflicting,
+	ErrorUserIsNotDeleted,
+	ErrorUserIsErased,
+	ErrorRetentionDatasetIsNotValid,
+	ErrorUserImportIsEmpty,
+	ErrorUserImportIsRolledBack,
+	ErrorUserImportIsTooLong,
+	ErrorUserExportColumnIsNotValid,
+	ErrorUserBatchIsEmpty,
+	ErrorUserBatchIsTooLong,
+	ErrorUserBatchIsRolledBack,
+	ErrorUserBatchTagsIsEmpty,
+	ErrorUserPageTokenIsNotValid,
+	ErrorUserQueryIsNotValid,
 }
 
 const (
@@ -44,6 +78,25 @@ const (
 	ErrEmail           string = "email"
 	ErrRole            string = "role"
 	ErrUserNameOrEmail string = "username_or_email"
+	ErrRegistration    string = "registration"
+	ErrEmailDomain     string = "emaildomain"
+	ErrStatus          string = "status"
+	ErrTransition      string = "transition"
+	ErrReason          string = "reason"
+	ErrSuspendedUntil  string = "suspendeduntil"
+	ErrActivityType    string = "activitytype"
+	ErrDeadLetter      string = "deadletter"
+	ErrChannel         string = "channel"
+	ErrRequest         string = "request"
+	ErrRetention       string = "retention"
+	ErrDataset         string = "dataset"
+	ErrImport          string = "import"
+	ErrExport          string = "export"
+	ErrColumn          string = "column"
+	ErrBatch           string = "batch"
+	ErrTags            string = "tags"
+	ErrPageToken       string = "pagetoken"
+	ErrQuery           string = "query"
 )
 
 const (
@@ -54,6 +107,21 @@ const (
 	ErrContainsSpecialChar string = "containsSpecialChar"
 	ErrInactive            string = "inactive"
 	ErrAlreadyExists       string = "alreadyexists"
+	ErrDisabled            string = "disabled"
+	ErrRateLimitExceeded   string = "ratelimitexceeded"
+	ErrNotAllowed          string = "notallowed"
+	ErrDisposable          string = "disposable"
+	ErrPending             string = "pending"
+	ErrSuspended           string = "suspended"
+	ErrLocked              string = "locked"
+	ErrDeleted             string = "deleted"
+	ErrNotChanged          string = "notchanged"
+	ErrExpired             string = "expired"
+	ErrInProgress          string = "inprogress"
+	ErrConflicting         string = "conflicting"
+	ErrNotDeleted          string = "notdeleted"
+	ErrErased              string = "erased"
+	ErrRolledBack          string = "rolledback"
 )
 
 var (
@@ -82,6 +150,42 @@ var (
 	ErrorUserPasswordIsNotValid error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrPassword + smodel.ErrSep + ErrNotValid)
 	ErrorUserPasswordNotFound   error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrPassword + smodel.ErrSep + smodel.ErrNotFound)
 	ErrorUserPasswordIsInactive error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrPassword + smodel.ErrSep + ErrInactive)
+
+	ErrorUserRegistrationIsDisabled        error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrRegistration + smodel.ErrSep + ErrDisabled)
+	ErrorUserRegistrationRateLimitExceeded error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrRegistration + smodel.ErrSep + ErrRateLimitExceeded)
+	ErrorUserEmailDomainIsNotAllowed       error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrEmailDomain + smodel.ErrSep + ErrNotAllowed)
+	ErrorUserEmailDomainIsDisposable       error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrEmailDomain + smodel.ErrSep + ErrDisposable)
+
+	ErrorUserIsPending                  error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrPending)
+	ErrorUserIsSuspended                error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrSuspended)
+	ErrorUserIsLocked                   error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrLocked)
+	ErrorUserIsDeleted                  error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrDeleted)
+	ErrorUserStatusIsEmpty              error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrStatus + smodel.ErrSep + ErrEmpty)
+	ErrorUserStatusIsNotChanged         error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrStatus + smodel.ErrSep + ErrNotChanged)
+	ErrorUserStatusDeletedIsNotAllowed  error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrStatus + smodel.ErrSep + ErrDeleted + smodel.ErrSep + ErrNotAllowed)
+	ErrorUserStatusTransitionIsNotValid error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrStatus + smodel.ErrSep + ErrTransition + smodel.ErrSep + ErrNotValid)
+	ErrorUserStatusReasonIsEmpty        error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrStatus + smodel.ErrSep + ErrReason + smodel.ErrSep + ErrEmpty)
+	ErrorUserIsExpired                  error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrExpired)
+	ErrorUserSuspendedUntilIsNotValid   error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrSuspendedUntil + smodel.ErrSep + ErrNotValid)
+	ErrorUserActivityTypeIsEmpty        error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrActivityType + smodel.ErrSep + ErrEmpty)
+	ErrorDeadLetterNotFound             error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrDeadLetter + smodel.ErrSep + smodel.ErrNotFound)
+	ErrorDeadLetterChannelIsNotValid    error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrDeadLetter + smodel.ErrSep + ErrChannel + smodel.ErrSep + ErrNotValid)
+	ErrorDeadLetterChannelIsNotAllowed  error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrDeadLetter + smodel.ErrSep + ErrChannel + smodel.ErrSep + ErrNotAllowed)
+	ErrorUserRequestIsInProgress        error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrRequest + smodel.ErrSep + ErrInProgress)
+	ErrorUserRequestIsConflicting       error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrRequest + smodel.ErrSep + ErrConflicting)
+	ErrorUserIsNotDeleted               error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrNotDeleted)
+	ErrorUserIsErased                   error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrErased)
+	ErrorRetentionDatasetIsNotValid     error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrRetention + smodel.ErrSep + ErrDataset + smodel.ErrSep + ErrNotValid)
+	ErrorUserImportIsEmpty              error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrImport + smodel.ErrSep + ErrEmpty)
+	ErrorUserImportIsRolledBack         error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrImport + smodel.ErrSep + ErrRolledBack)
+	ErrorUserImportIsTooLong            error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrImport + smodel.ErrSep + ErrTooLong)
+	ErrorUserExportColumnIsNotValid     error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrExport + smodel.ErrSep + ErrColumn + smodel.ErrSep + ErrNotValid)
+	ErrorUserBatchIsEmpty               error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrBatch + smodel.ErrSep + ErrEmpty)
+	ErrorUserBatchIsTooLong             error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrBatch + smodel.ErrSep + ErrTooLong)
+	ErrorUserBatchIsRolledBack          error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrBatch + smodel.ErrSep + ErrRolledBack)
+	ErrorUserBatchTagsIsEmpty           error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrBatch + smodel.ErrSep + ErrTags + smodel.ErrSep + ErrEmpty)
+	ErrorUserPageTokenIsNotValid        error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrPageToken + smodel.ErrSep + ErrNotValid)
+	ErrorUserQueryIsNotValid            error = errors.New(smodel.ErrBase + smodel.ErrSep + ErrUser + smodel.ErrSep + ErrQuery + smodel.ErrSep + ErrNotValid)
 )
 
 func GetErrors() []error {
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+)
+
+const (
+	QueryKeyCorrelationId  smodel.QueryKey = "cid" // QueryKeyCorrelationId is the context key of the id of the request that caused the call.
+	QueryKeyIdempotencyKey smodel.QueryKey = "idk" // QueryKeyIdempotencyKey is the context key of the idempotency key of the request.
+)
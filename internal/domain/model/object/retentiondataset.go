@@ -0,0 +1,12 @@
+package domain
+
+// RetentionDataset is a type that represents a dataset that is purged after its retention period.
+type RetentionDataset int8
+
+const (
+	RetentionDatasetNONE RetentionDataset = iota
+	RetentionDatasetDELETEDUSER
+	RetentionDatasetINACTIVEPASSWORD
+	RetentionDatasetLOGINHISTORY
+	RetentionDatasetSENTOUTBOXEVENT
+)
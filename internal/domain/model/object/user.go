@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"time"
 )
 
 // User is a struct that represents the object of a user basic values.
@@ -14,6 +15,9 @@ type User struct {
 	Tags       []string   `json:"tags"`        // Tags is the tags of the user.
 	FirstName  string     `json:"first_name"`  // FirstName is the first name of the user.
 	LastName   string     `json:"last_name"`   // LastName is the last name of the user.
+
+	SuspendedUntil time.Time `json:"suspended_until"` // SuspendedUntil is the end of the suspension of the user.
+	ExpiresAt      time.Time `json:"expires_at"`      // ExpiresAt is the expiry time of the user account.
 }
 
 // NewUser creates a new *User.
@@ -24,7 +28,9 @@ func NewUser(userName string,
 	userStatus UserStatus,
 	tags []string,
 	firstName string,
-	lastName string) *User {
+	lastName string,
+	suspendedUntil time.Time,
+	expiresAt time.Time) *User {
 	return &User{
 		UserName:   userName,
 		Email:      email,
@@ -34,6 +40,9 @@ func NewUser(userName string,
 		Tags:       tags,
 		FirstName:  firstName,
 		LastName:   lastName,
+
+		SuspendedUntil: suspendedUntil,
+		ExpiresAt:      expiresAt,
 	}
 }
 
@@ -48,6 +57,9 @@ func NewEmptyUser() *User {
 		Tags:       []string{},
 		FirstName:  "",
 		LastName:   "",
+
+		SuspendedUntil: time.Time{},
+		ExpiresAt:      time.Time{},
 	}
 }
 
@@ -60,7 +72,9 @@ func (s *User) String() string {
 		"UserStatus: %v, "+
 		"Tags: %v, "+
 		"FirstName: %v, "+
-		"LastName: %v",
+		"LastName: %v, "+
+		"SuspendedUntil: %v, "+
+		"ExpiresAt: %v",
 		s.UserName,
 		s.Email,
 		s.Role,
@@ -68,7 +82,9 @@ func (s *User) String() string {
 		s.UserStatus,
 		s.Tags,
 		s.FirstName,
-		s.LastName)
+		s.LastName,
+		s.SuspendedUntil,
+		s.ExpiresAt)
 }
 
 // Equals returns true if the User is equal to the other User.
@@ -99,6 +115,12 @@ func (s *User) Equals(other *User) bool {
 	if s.LastName != other.LastName {
 		return false
 	}
+	if !s.SuspendedUntil.Equal(other.SuspendedUntil) {
+		return false
+	}
+	if !s.ExpiresAt.Equal(other.ExpiresAt) {
+		return false
+	}
 	return true
 }
 
@@ -113,6 +135,9 @@ func (s *User) Clone() *User {
 		Tags:       s.Tags,
 		FirstName:  s.FirstName,
 		LastName:   s.LastName,
+
+		SuspendedUntil: s.SuspendedUntil,
+		ExpiresAt:      s.ExpiresAt,
 	}
 }
 
@@ -142,6 +167,12 @@ func (s *User) IsEmpty() bool {
 	if s.LastName != "" {
 		return false
 	}
+	if !s.SuspendedUntil.IsZero() {
+		return false
+	}
+	if !s.ExpiresAt.IsZero() {
+		return false
+	}
 	return true
 }
 
@@ -160,6 +191,18 @@ func (s *User) Clear() {
 	s.Tags = []string{}
 	s.FirstName = ""
 	s.LastName = ""
+	s.SuspendedUntil = time.Time{}
+	s.ExpiresAt = time.Time{}
+}
+
+// IsExpired returns true if the User account has an expiry time and it has passed at the given time.
+func (s *User) IsExpired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// IsSuspensionOver returns true if the User is suspended until a time and it has passed at the given time.
+func (s *User) IsSuspensionOver(now time.Time) bool {
+	return s.UserStatus == UserStatusSUSPENDED && !s.SuspendedUntil.IsZero() && !now.Before(s.SuspendedUntil)
 }
 
 // Validate validates the User.
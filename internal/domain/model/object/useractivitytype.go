@@ -0,0 +1,10 @@
+package domain
+
+// UserActivityType is a type that represents the type of an activity of a user.
+type UserActivityType int8
+
+const (
+	UserActivityTypeNONE UserActivityType = iota
+	UserActivityTypeLOGIN
+	UserActivityTypeREQUEST
+)
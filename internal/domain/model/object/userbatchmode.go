@@ -0,0 +1,10 @@
+package domain
+
+// UserBatchMode is a type that represents how the items of a user batch command are committed.
+type UserBatchMode int8
+
+const (
+	UserBatchModeNONE UserBatchMode = iota
+	UserBatchModeTRANSACTIONAL
+	UserBatchModeBESTEFFORT
+)
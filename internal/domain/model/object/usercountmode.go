@@ -0,0 +1,11 @@
+package domain
+
+// UserCountMode is a type that represents how the total number of the users of a filter is counted.
+type UserCountMode int8
+
+const (
+	UserCountModeNONE      UserCountMode = iota // NONE counts exactly like EXACT for the existing clients.
+	UserCountModeEXACT                          // EXACT counts the matching users.
+	UserCountModeESTIMATED                      // ESTIMATED takes the number of the rows the database planner expects from its statistics.
+	UserCountModeSKIP                           // SKIP does not count, the total number of rows is -1.
+)
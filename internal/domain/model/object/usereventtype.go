@@ -0,0 +1,16 @@
+package domain
+
+// UserEventType is a type that represents the type of a user domain event.
+type UserEventType int8
+
+const (
+	UserEventTypeNONE UserEventType = iota
+	UserEventTypeCREATED
+	UserEventTypeUPDATED
+	UserEventTypeSTATUSCHANGED
+	UserEventTypeROLECHANGED
+	UserEventTypePASSWORDCHANGED
+	UserEventTypeDELETED
+	UserEventTypeRESTORED
+	UserEventTypeERASED
+)
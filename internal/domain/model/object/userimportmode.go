@@ -0,0 +1,10 @@
+package domain
+
+// UserImportMode is a type that represents how the rows of a user import are committed.
+type UserImportMode int8
+
+const (
+	UserImportModeNONE UserImportMode = iota
+	UserImportModeALLORNOTHING
+	UserImportModeBESTEFFORT
+)
@@ -9,4 +9,5 @@ const (
 	UserSortFieldName
 	UserSortFieldCreatedAt
 	UserSortFieldUpdatedAt
+	UserSortFieldLastLogin
 )
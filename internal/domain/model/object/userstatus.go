@@ -6,5 +6,10 @@ type UserStatus int8
 const (
 	UserStatusNONE UserStatus = iota
 	UserStatusACTIVE
-	UserStatusINACTIVE
+	UserStatusINACTIVE // INACTIVE is kept for the existing records, it behaves like DEACTIVATED.
+	UserStatusPENDING
+	UserStatusSUSPENDED
+	UserStatusLOCKED
+	UserStatusDEACTIVATED
+	UserStatusDELETED
 )
@@ -0,0 +1,51 @@
+package domain
+
+// UserStatusTransition is a struct that represents the guard of a user status transition.
+type UserStatusTransition struct {
+	ReasonRequired bool // ReasonRequired is true if the transition can not be applied without a reason.
+}
+
+// UserStatusUpdateReason is the reason recorded for the transitions of the legacy status updates that are sent without a reason.
+// It is only recorded, the transitions that require a reason are not allowed for the legacy status updates.
+const UserStatusUpdateReason string = "legacy status update"
+
+// UserStatusTransitions is the lifecycle of a user, it holds the allowed target statuses of each status.
+var UserStatusTransitions map[UserStatus]map[UserStatus]UserStatusTransition = map[UserStatus]map[UserStatus]UserStatusTransition{
+	UserStatusNONE: {
+		UserStatusPENDING:  {ReasonRequired: false},
+		UserStatusACTIVE:   {ReasonRequired: false},
+		UserStatusINACTIVE: {ReasonRequired: false}, // INACTIVE is kept for the legacy clients.
+	},
+	UserStatusPENDING: {
+		UserStatusACTIVE:      {ReasonRequired: false},
+		UserStatusDEACTIVATED: {ReasonRequired: true},
+		UserStatusDELETED:     {ReasonRequired: false},
+	},
+	UserStatusACTIVE: {
+		UserStatusINACTIVE:    {ReasonRequired: false}, // INACTIVE is kept for the legacy clients.
+		UserStatusSUSPENDED:   {ReasonRequired: true},
+		UserStatusLOCKED:      {ReasonRequired: true},
+		UserStatusDEACTIVATED: {ReasonRequired: true},
+		UserStatusDELETED:     {ReasonRequired: false},
+	},
+	UserStatusSUSPENDED: {
+		UserStatusACTIVE:      {ReasonRequired: false},
+		UserStatusDEACTIVATED: {ReasonRequired: true},
+		UserStatusDELETED:     {ReasonRequired: false},
+	},
+	UserStatusLOCKED: {
+		UserStatusACTIVE:      {ReasonRequired: true},
+		UserStatusDEACTIVATED: {ReasonRequired: true},
+		UserStatusDELETED:     {ReasonRequired: false},
+	},
+	UserStatusDEACTIVATED: {
+		UserStatusACTIVE:  {ReasonRequired: true},
+		UserStatusDELETED: {ReasonRequired: false},
+	},
+	UserStatusINACTIVE: {
+		UserStatusACTIVE:      {ReasonRequired: true},
+		UserStatusDEACTIVATED: {ReasonRequired: false},
+		UserStatusDELETED:     {ReasonRequired: false},
+	},
+	UserStatusDELETED: {},
+}
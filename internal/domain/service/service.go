@@ -2,6 +2,7 @@ package domain
 
 import (
 	"strings"
+	"time"
 	"unicode"
 
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
@@ -85,6 +86,35 @@ func (s *Service) CheckEmailRules(user *me.User) error {
 	return nil
 }
 
+// CheckEmailDomainRules checks the email domain against the allowed, denied and disposable domain lists
+func (s *Service) CheckEmailDomainRules(user *me.User, allowedDomains []string, deniedDomains []string, disposableDomains []string) error {
+	at := strings.LastIndex(user.Email, "@")
+	if at < 0 {
+		return mo.ErrorUserEmailIsNotValid
+	}
+	domain := strings.ToLower(user.Email[at+1:])
+	if len(allowedDomains) > 0 && !matchesDomain(domain, allowedDomains) {
+		return mo.ErrorUserEmailDomainIsNotAllowed
+	}
+	if matchesDomain(domain, deniedDomains) {
+		return mo.ErrorUserEmailDomainIsNotAllowed
+	}
+	if matchesDomain(domain, disposableDomains) {
+		return mo.ErrorUserEmailDomainIsDisposable
+	}
+	return nil
+}
+
+func matchesDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(d)
+		if domain == d || strings.HasSuffix(domain, "."+d) { // Subdomains match their parent domain
+			return true
+		}
+	}
+	return false
+}
+
 // CheckPasswordRules checks the rules for passwords
 func (s *Service) CheckPasswordRules(userPassword *me.UserPassword) error {
 	if userPassword.Password == "" {
@@ -104,8 +134,113 @@ func (s *Service) CheckPasswordRules(userPassword *me.UserPassword) error {
 
 // CheckIsAuthenticable checks if a user is authenticable based on their status
 func (s *Service) CheckIsAuthenticable(user *me.User) error {
-	if user.UserStatus == mo.UserStatusINACTIVE {
+	if user.IsExpired(time.Now()) {
+		return mo.ErrorUserIsExpired
+	}
+	switch user.UserStatus {
+	case mo.UserStatusINACTIVE, mo.UserStatusDEACTIVATED:
 		return mo.ErrorUserIsInactive
+	case mo.UserStatusPENDING:
+		return mo.ErrorUserIsPending
+	case mo.UserStatusSUSPENDED:
+		return mo.ErrorUserIsSuspended
+	case mo.UserStatusLOCKED:
+		return mo.ErrorUserIsLocked
+	case mo.UserStatusDELETED:
+		return mo.ErrorUserIsDeleted
+	}
+	return nil
+}
+
+// CheckUserStatusTransition checks if the user can be moved from the given status to the target status
+func (s *Service) CheckUserStatusTransition(fromStatus mo.UserStatus, toStatus mo.UserStatus, reason string) error {
+	if toStatus == mo.UserStatusNONE {
+		return mo.ErrorUserStatusIsEmpty
+	}
+	if fromStatus == toStatus {
+		return mo.ErrorUserStatusIsNotChanged
+	}
+	transition, ok := mo.UserStatusTransitions[fromStatus][toStatus]
+	if !ok {
+		return mo.ErrorUserStatusTransitionIsNotValid
+	}
+	if transition.ReasonRequired && strings.TrimSpace(reason) == "" {
+		return mo.ErrorUserStatusReasonIsEmpty
+	}
+	return nil
+}
+
+// CheckSuspensionRules checks that a suspension with an end time ends in the future
+func (s *Service) CheckSuspensionRules(userStatusChange *me.UserStatusChange, now time.Time) error {
+	if userStatusChange.UserStatus != mo.UserStatusSUSPENDED || userStatusChange.SuspendedUntil.IsZero() {
+		return nil
+	}
+	if !userStatusChange.SuspendedUntil.After(now) {
+		return mo.ErrorUserSuspendedUntilIsNotValid
+	}
+	return nil
+}
+
+// CheckUserRestore checks if the user is soft-deleted, so that it can be restored
+func (s *Service) CheckUserRestore(user *me.User) error {
+	if !user.ErasedAt.IsZero() {
+		return mo.ErrorUserIsErased
+	}
+	if user.DeletedAt.IsZero() {
+		return mo.ErrorUserIsNotDeleted
 	}
 	return nil
 }
+
+// GetRestoredUserStatus returns the status the user had before the deletion by the given latest status transition.
+// The user is restored as deactivated if the status before the deletion is not known.
+func (s *Service) GetRestoredUserStatus(lastUserStatusHistory *me.UserStatusHistory) mo.UserStatus {
+	if lastUserStatusHistory == nil || lastUserStatusHistory.ToStatus != mo.UserStatusDELETED {
+		return mo.UserStatusDEACTIVATED
+	}
+	if _, ok := mo.UserStatusTransitions[lastUserStatusHistory.FromStatus]; !ok || lastUserStatusHistory.FromStatus == mo.UserStatusNONE {
+		return mo.UserStatusDEACTIVATED
+	}
+	return lastUserStatusHistory.FromStatus
+}
+
+// GetErasedUser returns the anonymized tombstone of the user, it keeps only the id, the role and the type of the user.
+func (s *Service) GetErasedUser(user *me.User) me.User {
+	erasedUser := *me.NewEmptyUser()
+	erasedUser.Id = user.Id
+	erasedUser.UserName = "erased-" + user.Id.String()
+	erasedUser.Role = user.Role
+	erasedUser.UserType = user.UserType
+	erasedUser.UserStatus = mo.UserStatusDELETED
+	return erasedUser
+}
+
+// AddUserTags adds the given tags to the user, the tags the user already has are not repeated.
+func (s *Service) AddUserTags(user *me.User, tags []string) {
+	for _, tag := range tags {
+		if !containsTag(user.Tags, tag) {
+			user.Tags = append(user.Tags, tag)
+		}
+	}
+}
+
+// RemoveUserTags removes the given tags from the user.
+func (s *Service) RemoveUserTags(user *me.User, tags []string) {
+	userTags := []string{}
+	for _, tag := range user.Tags {
+		if !containsTag(tags, tag) {
+			userTags = append(userTags, tag)
+		}
+	}
+	user.Tags = userTags
+}
+
+// containsTag returns true if the given tags contain the tag.
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
@@ -129,3 +131,424 @@ func TestService_CheckUserNameRules(t *testing.T) {
 		})
 	}
 }
+
+func TestService_CheckEmailDomainRules(t *testing.T) {
+
+	type args struct {
+		user              *me.User
+		allowedDomains    []string
+		deniedDomains     []string
+		disposableDomains []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "No Rules",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@example.com",
+				},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "Allowed Domain",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@example.com",
+				},
+			}, allowedDomains: []string{"example.com"}},
+			wantErr: nil,
+		},
+		{
+			name: "Allowed Subdomain",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@mail.Example.com",
+				},
+			}, allowedDomains: []string{"example.com"}},
+			wantErr: nil,
+		},
+		{
+			name: "Not In Allowed Domains",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@other.com",
+				},
+			}, allowedDomains: []string{"example.com"}},
+			wantErr: mo.ErrorUserEmailDomainIsNotAllowed,
+		},
+		{
+			name: "Similar Suffix Is Not Allowed",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@notexample.com",
+				},
+			}, allowedDomains: []string{"example.com"}},
+			wantErr: mo.ErrorUserEmailDomainIsNotAllowed,
+		},
+		{
+			name: "Denied Domain",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@competitor.com",
+				},
+			}, deniedDomains: []string{"competitor.com"}},
+			wantErr: mo.ErrorUserEmailDomainIsNotAllowed,
+		},
+		{
+			name: "Disposable Domain",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john@mailinator.com",
+				},
+			}, disposableDomains: []string{"mailinator.com"}},
+			wantErr: mo.ErrorUserEmailDomainIsDisposable,
+		},
+		{
+			name: "Missing At Sign",
+			args: args{user: &me.User{
+				User: mo.User{
+					Email: "john.example.com",
+				},
+			}},
+			wantErr: mo.ErrorUserEmailIsNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			if err := s.CheckEmailDomainRules(tt.args.user, tt.args.allowedDomains, tt.args.deniedDomains, tt.args.disposableDomains); err != tt.wantErr {
+				t.Errorf("Service.CheckEmailDomainRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CheckUserStatusTransition(t *testing.T) {
+
+	type args struct {
+		fromStatus mo.UserStatus
+		toStatus   mo.UserStatus
+		reason     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "Create Pending User",
+			args:    args{fromStatus: mo.UserStatusNONE, toStatus: mo.UserStatusPENDING},
+			wantErr: nil,
+		},
+		{
+			name:    "Activate Pending User",
+			args:    args{fromStatus: mo.UserStatusPENDING, toStatus: mo.UserStatusACTIVE},
+			wantErr: nil,
+		},
+		{
+			name:    "Suspend Active User",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusSUSPENDED, reason: "abuse report"},
+			wantErr: nil,
+		},
+		{
+			name:    "Suspend Active User Without Reason",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusSUSPENDED, reason: " "},
+			wantErr: mo.ErrorUserStatusReasonIsEmpty,
+		},
+		{
+			name:    "Lock Pending User",
+			args:    args{fromStatus: mo.UserStatusPENDING, toStatus: mo.UserStatusLOCKED, reason: "too many attempts"},
+			wantErr: mo.ErrorUserStatusTransitionIsNotValid,
+		},
+		{
+			name:    "Revive Deleted User",
+			args:    args{fromStatus: mo.UserStatusDELETED, toStatus: mo.UserStatusACTIVE, reason: "mistake"},
+			wantErr: mo.ErrorUserStatusTransitionIsNotValid,
+		},
+		{
+			name:    "Same Status",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusACTIVE},
+			wantErr: mo.ErrorUserStatusIsNotChanged,
+		},
+		{
+			name:    "Empty Status",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusNONE},
+			wantErr: mo.ErrorUserStatusIsEmpty,
+		},
+		{
+			name:    "Create Legacy Inactive User",
+			args:    args{fromStatus: mo.UserStatusNONE, toStatus: mo.UserStatusINACTIVE},
+			wantErr: nil,
+		},
+		{
+			name:    "Inactivate Active User Without Reason",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusINACTIVE},
+			wantErr: nil,
+		},
+		{
+			name:    "Deactivate Active User By Legacy Update",
+			args:    args{fromStatus: mo.UserStatusACTIVE, toStatus: mo.UserStatusDEACTIVATED},
+			wantErr: mo.ErrorUserStatusReasonIsEmpty,
+		},
+		{
+			name:    "Deactivate Legacy Inactive User",
+			args:    args{fromStatus: mo.UserStatusINACTIVE, toStatus: mo.UserStatusDEACTIVATED},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			if err := s.CheckUserStatusTransition(tt.args.fromStatus, tt.args.toStatus, tt.args.reason); err != tt.wantErr {
+				t.Errorf("Service.CheckUserStatusTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CheckIsAuthenticable(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		userStatus mo.UserStatus
+		expiresAt  time.Time
+		wantErr    error
+	}{
+		{name: "Active", userStatus: mo.UserStatusACTIVE, wantErr: nil},
+		{name: "Inactive", userStatus: mo.UserStatusINACTIVE, wantErr: mo.ErrorUserIsInactive},
+		{name: "Pending", userStatus: mo.UserStatusPENDING, wantErr: mo.ErrorUserIsPending},
+		{name: "Suspended", userStatus: mo.UserStatusSUSPENDED, wantErr: mo.ErrorUserIsSuspended},
+		{name: "Locked", userStatus: mo.UserStatusLOCKED, wantErr: mo.ErrorUserIsLocked},
+		{name: "Deactivated", userStatus: mo.UserStatusDEACTIVATED, wantErr: mo.ErrorUserIsInactive},
+		{name: "Deleted", userStatus: mo.UserStatusDELETED, wantErr: mo.ErrorUserIsDeleted},
+		{name: "Active Not Yet Expired", userStatus: mo.UserStatusACTIVE, expiresAt: time.Now().Add(time.Hour), wantErr: nil},
+		{name: "Active But Expired", userStatus: mo.UserStatusACTIVE, expiresAt: time.Now().Add(-time.Hour), wantErr: mo.ErrorUserIsExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			user := &me.User{User: mo.User{UserStatus: tt.userStatus, ExpiresAt: tt.expiresAt}}
+			if err := s.CheckIsAuthenticable(user); err != tt.wantErr {
+				t.Errorf("Service.CheckIsAuthenticable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CheckSuspensionRules(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		userStatusChange *me.UserStatusChange
+		wantErr          error
+	}{
+		{
+			name:             "Suspend Without End",
+			userStatusChange: me.NewUserStatusChange(uuid.New(), mo.UserStatusSUSPENDED, "abuse report", time.Time{}),
+			wantErr:          nil,
+		},
+		{
+			name:             "Suspend Until Future",
+			userStatusChange: me.NewUserStatusChange(uuid.New(), mo.UserStatusSUSPENDED, "abuse report", now.Add(24*time.Hour)),
+			wantErr:          nil,
+		},
+		{
+			name:             "Suspend Until Past",
+			userStatusChange: me.NewUserStatusChange(uuid.New(), mo.UserStatusSUSPENDED, "abuse report", now.Add(-24*time.Hour)),
+			wantErr:          mo.ErrorUserSuspendedUntilIsNotValid,
+		},
+		{
+			name:             "Not A Suspension",
+			userStatusChange: me.NewUserStatusChange(uuid.New(), mo.UserStatusACTIVE, "", time.Time{}),
+			wantErr:          nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			if err := s.CheckSuspensionRules(tt.userStatusChange, now); err != tt.wantErr {
+				t.Errorf("Service.CheckSuspensionRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CheckUserRestore(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		deletedAt time.Time
+		erasedAt  time.Time
+		wantErr   error
+	}{
+		{name: "Deleted", deletedAt: time.Now(), wantErr: nil},
+		{name: "Not Deleted", deletedAt: time.Time{}, wantErr: mo.ErrorUserIsNotDeleted},
+		{name: "Erased", deletedAt: time.Now(), erasedAt: time.Now(), wantErr: mo.ErrorUserIsErased},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			user := &me.User{DeletedAt: tt.deletedAt, ErasedAt: tt.erasedAt}
+			if err := s.CheckUserRestore(user); err != tt.wantErr {
+				t.Errorf("Service.CheckUserRestore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_GetRestoredUserStatus(t *testing.T) {
+
+	tests := []struct {
+		name                  string
+		lastUserStatusHistory *me.UserStatusHistory
+		want                  mo.UserStatus
+	}{
+		{
+			name:                  "Deleted While Active",
+			lastUserStatusHistory: me.NewUserStatusHistory(uuid.New(), uuid.New(), mo.UserStatusACTIVE, mo.UserStatusDELETED, "", uuid.New()),
+			want:                  mo.UserStatusACTIVE,
+		},
+		{
+			name:                  "Deleted While Suspended",
+			lastUserStatusHistory: me.NewUserStatusHistory(uuid.New(), uuid.New(), mo.UserStatusSUSPENDED, mo.UserStatusDELETED, "", uuid.New()),
+			want:                  mo.UserStatusSUSPENDED,
+		},
+		{
+			name:                  "Last Transition Is Not A Deletion",
+			lastUserStatusHistory: me.NewUserStatusHistory(uuid.New(), uuid.New(), mo.UserStatusPENDING, mo.UserStatusACTIVE, "", uuid.New()),
+			want:                  mo.UserStatusDEACTIVATED,
+		},
+		{
+			name:                  "No History",
+			lastUserStatusHistory: nil,
+			want:                  mo.UserStatusDEACTIVATED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			if got := s.GetRestoredUserStatus(tt.lastUserStatusHistory); got != tt.want {
+				t.Errorf("Service.GetRestoredUserStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetErasedUser(t *testing.T) {
+	s := &Service{}
+	user := me.NewUser(uuid.New(), mo.User{
+		UserName:   "johndoe",
+		Email:      "john@doe.com",
+		Role:       "admin",
+		UserType:   mo.UserTypeADMIN,
+		UserStatus: mo.UserStatusACTIVE,
+		Tags:       []string{"vip"},
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+
+	got := s.GetErasedUser(user)
+	if got.Id != user.Id {
+		t.Errorf("Service.GetErasedUser() Id = %v, want %v", got.Id, user.Id)
+	}
+	if got.UserName != "erased-"+user.Id.String() {
+		t.Errorf("Service.GetErasedUser() UserName = %v, want %v", got.UserName, "erased-"+user.Id.String())
+	}
+	if got.Email != "" || got.FirstName != "" || got.LastName != "" || len(got.Tags) != 0 {
+		t.Errorf("Service.GetErasedUser() keeps personal data = %v", got.String())
+	}
+	if got.Role != user.Role || got.UserType != user.UserType {
+		t.Errorf("Service.GetErasedUser() Role, UserType = %v, %v, want %v, %v", got.Role, got.UserType, user.Role, user.UserType)
+	}
+	if got.UserStatus != mo.UserStatusDELETED {
+		t.Errorf("Service.GetErasedUser() UserStatus = %v, want %v", got.UserStatus, mo.UserStatusDELETED)
+	}
+}
+
+func TestService_AddUserTags(t *testing.T) {
+	type args struct {
+		user *me.User
+		tags []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Add To Empty Tags",
+			args: args{user: &me.User{User: mo.User{Tags: []string{}}}, tags: []string{"vip", "beta"}},
+			want: []string{"vip", "beta"},
+		},
+		{
+			name: "Existing Tag Is Not Repeated",
+			args: args{user: &me.User{User: mo.User{Tags: []string{"vip"}}}, tags: []string{"vip", "beta"}},
+			want: []string{"vip", "beta"},
+		},
+		{
+			name: "Repeated Tag Is Added Once",
+			args: args{user: &me.User{User: mo.User{Tags: []string{}}}, tags: []string{"beta", "beta"}},
+			want: []string{"beta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			s.AddUserTags(tt.args.user, tt.args.tags)
+			if !reflect.DeepEqual(tt.args.user.Tags, tt.want) {
+				t.Errorf("Service.AddUserTags() = %v, want %v", tt.args.user.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_RemoveUserTags(t *testing.T) {
+	type args struct {
+		user *me.User
+		tags []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Remove Existing Tag",
+			args: args{user: &me.User{User: mo.User{Tags: []string{"vip", "beta"}}}, tags: []string{"vip"}},
+			want: []string{"beta"},
+		},
+		{
+			name: "Remove Missing Tag",
+			args: args{user: &me.User{User: mo.User{Tags: []string{"vip"}}}, tags: []string{"beta"}},
+			want: []string{"vip"},
+		},
+		{
+			name: "Remove All Tags",
+			args: args{user: &me.User{User: mo.User{Tags: []string{"vip", "beta"}}}, tags: []string{"beta", "vip"}},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			s.RemoveUserTags(tt.args.user, tt.args.tags)
+			if !reflect.DeepEqual(tt.args.user.Tags, tt.want) {
+				t.Errorf("Service.RemoveUserTags() = %v, want %v", tt.args.user.Tags, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+)
+
+// Message is an entry of a stream of the event bus backend.
+type Message struct {
+	Id     string                 // Id is the id of the entry, it grows with the time the entry is added.
+	Values map[string]interface{} // Values are the fields of the entry, they are read back as strings.
+}
+
+// Backend is the stream storage that the event bus adapter runs on.
+// Every stream is read by the consumer group of the service, a read message stays pending until it is acknowledged.
+type Backend interface {
+	// CreateGroup creates the consumer group of the service on the stream, the stream is created if it does not exist.
+	CreateGroup(ctx context.Context, stream string) error
+
+	// Read returns the pending messages of the stream that are idle longer than the claim idle time, otherwise the new ones.
+	// It waits up to the block time for a new message and returns none if there is not any.
+	Read(ctx context.Context, stream string) ([]Message, error)
+
+	// DeliveryCount returns the number of the deliveries of the given pending message.
+	DeliveryCount(ctx context.Context, stream string, id string) int64
+
+	// Ack acknowledges the given messages so that they are not delivered again and removes them from the stream.
+	// The handled messages are not kept, so the streams do not grow and the password resets are not kept after they are handled.
+	Ack(ctx context.Context, stream string, ids ...string) error
+
+	// Add appends an entry with the given values to the stream and returns its id.
+	// The oldest entries are trimmed to about maxLen entries if it is greater than 0.
+	Add(ctx context.Context, stream string, values map[string]interface{}, maxLen int64) (string, error)
+
+	// Delete removes the given entries from the stream and returns the number of the removed ones.
+	Delete(ctx context.Context, stream string, ids ...string) (int64, error)
+
+	// Len returns the number of the entries of the stream.
+	Len(ctx context.Context, stream string) (int64, error)
+
+	// Range returns the entries of the stream between the start and the end ids, oldest first, at most count if it is not 0.
+	// "-" and "+" are the first and the last ids, an id with the "(" prefix is excluded.
+	Range(ctx context.Context, stream string, start string, end string, count int64) ([]Message, error)
+
+	// Drop deletes the stream with all of its entries.
+	Drop(ctx context.Context, stream string) error
+
+	// Expire deletes the stream after the ttl unless it is extended again.
+	Expire(ctx context.Context, stream string, ttl time.Duration) error
+
+	// SetKey stores the given key for the ttl.
+	SetKey(ctx context.Context, key string, value string, ttl time.Duration) error
+
+	// HasKey returns true if the given key is stored.
+	HasKey(ctx context.Context, key string) (bool, error)
+}
@@ -0,0 +1,640 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	map_ebus "github.com/octoposprime/op-be-user/pkg/infrastructure/mapper/ebus"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// testBackendConformance runs the behaviour every event bus backend must have against the backends created by newBackend.
+func testBackendConformance(t *testing.T, newBackend func(t *testing.T) Backend) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, backend Backend)
+	}{
+		{"RangeAndDelete", testRangeAndDelete},
+		{"AckRemovesMessage", testAckRemovesMessage},
+		{"DeliversCommand", testDeliversCommand},
+		{"RedeliversFailedCommand", testRedeliversFailedCommand},
+		{"DeadLettersAndReplays", testDeadLettersAndReplays},
+		{"DeadLettersMalformedMessage", testDeadLettersMalformedMessage},
+		{"SkipsDuplicatedEvent", testSkipsDuplicatedEvent},
+		{"KeepsOrderOfUser", testKeepsOrderOfUser},
+		{"KeepsOrderOfUserAfterFailure", testKeepsOrderOfUserAfterFailure},
+		{"KeepsOrderOfUserAcrossChannels", testKeepsOrderOfUserAcrossChannels},
+		{"RemovesPasswordReset", testRemovesPasswordReset},
+		{"AnswersQuery", testAnswersQuery},
+		{"RejectsMalformedQuery", testRejectsMalformedQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig()
+			backend := newBackend(t)
+			for _, stream := range []string{smodel.ChannelCreateUser, smodel.ChannelCreateUser + map_ebus.DeadLetterStreamSuffix, smodel.ChannelDeleteUser, mo.ChannelGetUsers, mo.ChannelGetUsers + map_ebus.DeadLetterStreamSuffix, mo.ChannelResetUserPassword, mo.ChannelResetUserPassword + map_ebus.DeadLetterStreamSuffix, "test:reply"} {
+				if err := backend.Drop(context.Background(), stream); err != nil {
+					t.Fatalf("Drop() error = %v", err)
+				}
+			}
+			tt.run(t, backend)
+		})
+	}
+}
+
+// setTestConfig sets the event bus config of the tests, a failed message is reclaimed at once.
+func setTestConfig() {
+	tconfig.ServiceConfigInstance = &tconfig.ServiceConfig{}
+	ebusConfig := &tconfig.ServiceConfigInstance.EBus
+	ebusConfig.Group = "test-" + uuid.New().String()
+	ebusConfig.Consumer = "test"
+	ebusConfig.BatchSize = 10
+	ebusConfig.Block = 1
+	ebusConfig.ClaimMinIdle = 0
+	ebusConfig.BackoffMin = 1
+	ebusConfig.BackoffMax = 1
+	ebusConfig.MaxAttempts = 3
+	ebusConfig.DedupTtl = 60
+	ebusConfig.Workers = 4
+	ebusConfig.QueueSize = 4
+	ebusConfig.QueryTimeout = 5
+	ebusConfig.QueryReplyTtl = 60
+	ebusConfig.QueryMaxLimit = 100
+}
+
+// newTestAdapter creates an adapter on the backend that does not print its logs and is closed at the end of the test.
+func newTestAdapter(t *testing.T, backend Backend) EBusAdapter {
+	adapter := NewEBusAdapter(backend)
+	adapter.SetLogger(func(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error) {
+		return &pb_logging.LoggingResult{}, nil
+	})
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		adapter.Close(ctx)
+	})
+	return adapter
+}
+
+// listen runs the Listen of the adapter until the end of the test.
+func listen(t *testing.T, adapter EBusAdapter, channelName string, callBack func(ctx context.Context, channelName string, userCommand me.UserCommand) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		adapter.Listen(ctx, channelName, callBack)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// addCommand adds a create user command in an envelope of the given id to the backend.
+func addCommand(t *testing.T, backend Backend, envelopeId string, user *pb.User) string {
+	payload, err := json.Marshal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := json.Marshal(map_ebus.NewEnvelope(envelopeId, smodel.ChannelCreateUser, "test", "cid-"+envelopeId, "", time.Now(), payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := backend.Add(context.Background(), smodel.ChannelCreateUser, map[string]interface{}{messageField: string(message)}, 0)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return id
+}
+
+// eventually fails the test if the condition does not hold within the timeout.
+func eventually(t *testing.T, timeout time.Duration, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition is not met in time")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func testRangeAndDelete(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	stream := "test:reply"
+	ids := make([]string, 3)
+	for i := range ids {
+		id, err := backend.Add(ctx, stream, map[string]interface{}{"index": i}, 0)
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids[i] = id
+	}
+	messages, err := backend.Range(ctx, stream, "("+ids[0], "+", 0)
+	if err != nil || len(messages) != 2 || messages[0].Id != ids[1] {
+		t.Fatalf("Range() = %v, %v, want the last 2 entries", messages, err)
+	}
+	if messages[0].Values["index"] != "1" {
+		t.Errorf("Range() values = %v, want the string values", messages[0].Values)
+	}
+	messages, err = backend.Range(ctx, stream, "-", "+", 1)
+	if err != nil || len(messages) != 1 || messages[0].Id != ids[0] {
+		t.Fatalf("Range() = %v, %v, want the first entry", messages, err)
+	}
+	count, err := backend.Delete(ctx, stream, ids[1])
+	if err != nil || count != 1 {
+		t.Fatalf("Delete() = %v, %v, want 1", count, err)
+	}
+	if length, _ := backend.Len(ctx, stream); length != 2 {
+		t.Errorf("Len() = %v, want 2", length)
+	}
+	if err := backend.Drop(ctx, stream); err != nil {
+		t.Fatalf("Drop() error = %v", err)
+	}
+	if length, _ := backend.Len(ctx, stream); length != 0 {
+		t.Errorf("Len() = %v after Drop(), want 0", length)
+	}
+}
+
+func testAckRemovesMessage(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	if err := backend.CreateGroup(ctx, smodel.ChannelCreateUser); err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := backend.Add(ctx, smodel.ChannelCreateUser, map[string]interface{}{"index": i}, 0); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	messages, err := backend.Read(ctx, smodel.ChannelCreateUser)
+	if err != nil || len(messages) != 2 {
+		t.Fatalf("Read() = %v, %v, want 2 messages", messages, err)
+	}
+	if err := backend.Ack(ctx, smodel.ChannelCreateUser, messages[0].Id); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	remaining, err := backend.Range(ctx, smodel.ChannelCreateUser, "-", "+", 0)
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Id != messages[1].Id {
+		t.Errorf("Range() = %v after Ack(), want only the unacknowledged message", remaining)
+	}
+	if count := backend.DeliveryCount(ctx, smodel.ChannelCreateUser, messages[1].Id); count != 1 {
+		t.Errorf("DeliveryCount() = %v for the unacknowledged message, want 1", count)
+	}
+}
+
+func testDeliversCommand(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	userId := uuid.New()
+	received := make(chan me.UserCommand, 1)
+	var correlationId string
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		correlationId, _ = ctx.Value(mo.QueryKeyCorrelationId).(string)
+		received <- userCommand
+		return nil
+	})
+	addCommand(t, backend, "event-1", &pb.User{Id: userId.String(), Username: "user"})
+	select {
+	case userCommand := <-received:
+		if userCommand.Id != "event-1" || userCommand.User.Id != userId || userCommand.User.UserName != "user" {
+			t.Errorf("callBack got %v, want the published user", userCommand)
+		}
+		if correlationId != "cid-event-1" {
+			t.Errorf("callBack correlation id = %v, want cid-event-1", correlationId)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("command is not delivered")
+	}
+}
+
+func testRedeliversFailedCommand(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	var mutex sync.Mutex
+	calls := 0
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	addCommand(t, backend, "event-1", &pb.User{Id: uuid.New().String()})
+	eventually(t, 10*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls == 2
+	})
+	time.Sleep(1500 * time.Millisecond)
+	mutex.Lock()
+	defer mutex.Unlock()
+	if calls != 2 {
+		t.Errorf("callBack is called %v times, want 2", calls)
+	}
+}
+
+func testDeadLettersAndReplays(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	var mutex sync.Mutex
+	failing := true
+	succeeded := 0
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failing {
+			return errors.New("permanent failure")
+		}
+		succeeded++
+		return nil
+	})
+	addCommand(t, backend, "event-1", &pb.User{Id: uuid.New().String()})
+	ctx := context.Background()
+	var deadLetters me.DeadLetters
+	eventually(t, 15*time.Second, func() bool {
+		deadLetters, _ = adapter.GetDeadLetters(ctx, me.DeadLetterFilter{ChannelName: smodel.ChannelCreateUser})
+		return len(deadLetters.DeadLetters) == 1
+	})
+	deadLetter := deadLetters.DeadLetters[0]
+	if deadLetter.Attempts != 3 || deadLetter.Error != "permanent failure" {
+		t.Errorf("dead letter = %v, want 3 attempts with the error", deadLetter)
+	}
+	if length, _ := backend.Len(ctx, smodel.ChannelCreateUser); length != 0 {
+		t.Errorf("channel length = %v after dead-lettering, want 0", length)
+	}
+	mutex.Lock()
+	failing = false
+	mutex.Unlock()
+	if err := adapter.ReplayDeadLetter(ctx, deadLetter); err != nil {
+		t.Fatalf("ReplayDeadLetter() error = %v", err)
+	}
+	eventually(t, 5*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return succeeded == 1
+	})
+	if length, _ := backend.Len(ctx, smodel.ChannelCreateUser+map_ebus.DeadLetterStreamSuffix); length != 0 {
+		t.Errorf("dead-letter length = %v after replay, want 0", length)
+	}
+}
+
+func testDeadLettersMalformedMessage(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	called := make(chan struct{}, 1)
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		called <- struct{}{}
+		return nil
+	})
+	ctx := context.Background()
+	if _, err := backend.Add(ctx, smodel.ChannelCreateUser, map[string]interface{}{messageField: "not a json"}, 0); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	eventually(t, 5*time.Second, func() bool {
+		count, _ := adapter.DeleteDeadLetters(ctx, smodel.ChannelCreateUser, nil)
+		return count == 1
+	})
+	select {
+	case <-called:
+		t.Error("callBack is called for a malformed message")
+	default:
+	}
+}
+
+func testSkipsDuplicatedEvent(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	var mutex sync.Mutex
+	calls := 0
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		return nil
+	})
+	userId := uuid.New().String()
+	addCommand(t, backend, "event-1", &pb.User{Id: userId})
+	eventually(t, 5*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls == 1
+	})
+	addCommand(t, backend, "event-1", &pb.User{Id: userId})
+	addCommand(t, backend, "event-2", &pb.User{Id: userId})
+	eventually(t, 5*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls == 2
+	})
+	time.Sleep(500 * time.Millisecond)
+	mutex.Lock()
+	defer mutex.Unlock()
+	if calls != 2 {
+		t.Errorf("callBack is called %v times, want 2", calls)
+	}
+}
+
+func testKeepsOrderOfUser(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	userIds := []string{uuid.New().String(), uuid.New().String()}
+	var mutex sync.Mutex
+	received := map[string][]int{}
+	running := map[string]bool{}
+	overlapped := false
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		userId := userCommand.User.Id.String()
+		mutex.Lock()
+		if running[userId] {
+			overlapped = true
+		}
+		running[userId] = true
+		mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		index, _ := strconv.Atoi(userCommand.User.FirstName)
+		mutex.Lock()
+		running[userId] = false
+		received[userId] = append(received[userId], index)
+		mutex.Unlock()
+		return nil
+	})
+	const count = 20
+	for i := 0; i < count; i++ {
+		userId := userIds[i%len(userIds)]
+		addCommand(t, backend, "event-"+strconv.Itoa(i), &pb.User{Id: userId, FirstName: strconv.Itoa(i)})
+	}
+	eventually(t, 10*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(received[userIds[0]])+len(received[userIds[1]]) == count
+	})
+	mutex.Lock()
+	defer mutex.Unlock()
+	if overlapped {
+		t.Error("the commands of a user are handled at the same time")
+	}
+	for _, userId := range userIds {
+		for i := 1; i < len(received[userId]); i++ {
+			if received[userId][i] < received[userId][i-1] {
+				t.Fatalf("the commands of %v are handled in the order %v", userId, received[userId])
+			}
+		}
+	}
+}
+
+func testKeepsOrderOfUserAfterFailure(t *testing.T, backend Backend) {
+	// The failed command is reclaimed later than the next command of the user is read.
+	tconfig.GetServiceConfigInstance().EBus.ClaimMinIdle = 2
+	adapter := newTestAdapter(t, backend)
+	userId := uuid.New().String()
+	var mutex sync.Mutex
+	calls := 0
+	received := []int{}
+	listen(t, adapter, smodel.ChannelCreateUser, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		index, _ := strconv.Atoi(userCommand.User.FirstName)
+		received = append(received, index)
+		return nil
+	})
+	addCommand(t, backend, "event-0", &pb.User{Id: userId, FirstName: "0"})
+	eventually(t, 10*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls == 1
+	})
+	addCommand(t, backend, "event-1", &pb.User{Id: userId, FirstName: "1"})
+	eventually(t, 15*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(received) == 2
+	})
+	mutex.Lock()
+	defer mutex.Unlock()
+	if received[0] != 0 || received[1] != 1 {
+		t.Errorf("the commands are handled in the order %v, want [0 1]", received)
+	}
+}
+
+func testKeepsOrderOfUserAcrossChannels(t *testing.T, backend Backend) {
+	// The failed create command is reclaimed later than the delete command of the user is read.
+	tconfig.GetServiceConfigInstance().EBus.ClaimMinIdle = 2
+	adapter := newTestAdapter(t, backend)
+	userId := uuid.New().String()
+	var mutex sync.Mutex
+	calls := 0
+	received := []string{}
+	callBack := func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		received = append(received, channelName)
+		return nil
+	}
+	listen(t, adapter, smodel.ChannelCreateUser, callBack)
+	listen(t, adapter, smodel.ChannelDeleteUser, callBack)
+	addCommand(t, backend, "event-0", &pb.User{Id: userId})
+	eventually(t, 10*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls == 1
+	})
+	payload, err := json.Marshal(&pb.User{Id: userId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := json.Marshal(map_ebus.NewEnvelope("event-1", smodel.ChannelDeleteUser, "test", "", "", time.Now(), payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Add(context.Background(), smodel.ChannelDeleteUser, map[string]interface{}{messageField: string(message)}, 0); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	eventually(t, 15*time.Second, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(received) == 2
+	})
+	mutex.Lock()
+	defer mutex.Unlock()
+	if received[0] != smodel.ChannelCreateUser || received[1] != smodel.ChannelDeleteUser {
+		t.Errorf("the commands are handled in the order %v, want the create before the delete", received)
+	}
+}
+
+func testRemovesPasswordReset(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	failingUserId := uuid.New()
+	handled := make(chan struct{}, 1)
+	listen(t, adapter, mo.ChannelResetUserPassword, func(ctx context.Context, channelName string, userCommand me.UserCommand) error {
+		if userCommand.UserPassword.UserId == failingUserId {
+			return errors.New("permanent failure")
+		}
+		handled <- struct{}{}
+		return nil
+	})
+	ctx := context.Background()
+	for _, userId := range []uuid.UUID{uuid.New(), failingUserId} {
+		payload, err := json.Marshal(&pb.UserPassword{UserId: userId.String(), Password: "secret-password"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		message, err := json.Marshal(map_ebus.NewEnvelope(uuid.New().String(), mo.ChannelResetUserPassword, "test", "", "", time.Now(), payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := backend.Add(ctx, mo.ChannelResetUserPassword, map[string]interface{}{messageField: string(message)}, 0); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	select {
+	case <-handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("password reset is not delivered")
+	}
+	var deadLetters me.DeadLetters
+	eventually(t, 15*time.Second, func() bool {
+		deadLetters, _ = adapter.GetDeadLetters(ctx, me.DeadLetterFilter{ChannelName: mo.ChannelResetUserPassword})
+		return len(deadLetters.DeadLetters) == 1
+	})
+	if strings.Contains(deadLetters.DeadLetters[0].Message, "secret-password") {
+		t.Errorf("dead letter message = %v, want the password redacted", deadLetters.DeadLetters[0].Message)
+	}
+	stored, err := backend.Range(ctx, mo.ChannelResetUserPassword+map_ebus.DeadLetterStreamSuffix, "-", "+", 0)
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	for _, message := range stored {
+		if value, _ := message.Values["message"].(string); strings.Contains(value, "secret-password") {
+			t.Errorf("dead-letter stream holds the password: %v", value)
+		}
+	}
+	eventually(t, 5*time.Second, func() bool {
+		length, _ := backend.Len(ctx, mo.ChannelResetUserPassword)
+		return length == 0
+	})
+}
+
+func testAnswersQuery(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	userId := uuid.New()
+	go func() {
+		adapter.ServeUserQueries(ctx, mo.ChannelGetUsers, func(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
+			if userFilter.UserName != "user" || userFilter.Limit != 100 {
+				return me.Users{}, errors.New("unexpected filter")
+			}
+			return me.Users{Users: []me.User{{Id: userId}}, TotalRows: 1}, nil
+		})
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	userName := "user"
+	payload, err := json.Marshal(&pb.UserFilter{Username: &userName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := map_ebus.NewEnvelope("query-1", mo.ChannelGetUsers, "test", "cid-1", "", time.Now(), payload)
+	query.ReplyTo = "test:reply"
+	message, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Add(context.Background(), mo.ChannelGetUsers, map[string]interface{}{messageField: string(message)}, 0); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	var replies []Message
+	eventually(t, 5*time.Second, func() bool {
+		replies, _ = backend.Range(context.Background(), "test:reply", "-", "+", 0)
+		return len(replies) == 1
+	})
+	replyMessage, _ := replies[0].Values[messageField].(string)
+	reply, err := map_ebus.DecodeEnvelope(replyMessage, mo.ChannelGetUsers)
+	if err != nil {
+		t.Fatalf("DecodeEnvelope() error = %v", err)
+	}
+	if reply.CorrelationId != "cid-1" || reply.Error != "" {
+		t.Fatalf("reply = %v, want the correlation id of the query and no error", reply)
+	}
+	var users pb.Users
+	if err := json.Unmarshal(reply.Payload, &users); err != nil {
+		t.Fatal(err)
+	}
+	if users.TotalRows != 1 || len(users.Users) != 1 || users.Users[0].Id != userId.String() {
+		t.Errorf("reply users = %v, want the user of the callBack", &users)
+	}
+}
+
+func testRejectsMalformedQuery(t *testing.T, backend Backend) {
+	adapter := newTestAdapter(t, backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var mutex sync.Mutex
+	called := false
+	go func() {
+		adapter.ServeUserQueries(ctx, mo.ChannelGetUsers, func(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			called = true
+			return me.Users{}, nil
+		})
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	query := map_ebus.NewEnvelope("query-1", mo.ChannelGetUsers, "test", "cid-1", "", time.Now(), []byte(`"garbled"`))
+	query.ReplyTo = "test:reply"
+	message, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Add(context.Background(), mo.ChannelGetUsers, map[string]interface{}{messageField: string(message)}, 0); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	var replies []Message
+	eventually(t, 5*time.Second, func() bool {
+		replies, _ = backend.Range(context.Background(), "test:reply", "-", "+", 0)
+		return len(replies) == 1
+	})
+	replyMessage, _ := replies[0].Values[messageField].(string)
+	reply, err := map_ebus.DecodeEnvelope(replyMessage, mo.ChannelGetUsers)
+	if err != nil {
+		t.Fatalf("DecodeEnvelope() error = %v", err)
+	}
+	if reply.Error != mo.ErrorUserQueryIsNotValid.Error() {
+		t.Fatalf("reply = %v, want the not valid query error", reply)
+	}
+	eventually(t, 5*time.Second, func() bool {
+		deadLetters, _ := backend.Len(context.Background(), mo.ChannelGetUsers+map_ebus.DeadLetterStreamSuffix)
+		return deadLetters == 1
+	})
+	mutex.Lock()
+	defer mutex.Unlock()
+	if called {
+		t.Error("callBack is called for a malformed query")
+	}
+}
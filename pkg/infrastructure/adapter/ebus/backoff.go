@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+)
+
+// backoff is an exponential delay between the consecutive failures of the event bus.
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+// newBackoff creates a new *backoff with the given limits in seconds.
+func newBackoff(minSeconds int, maxSeconds int) *backoff {
+	min := time.Duration(minSeconds) * time.Second
+	if min <= 0 {
+		min = time.Second
+	}
+	max := time.Duration(maxSeconds) * time.Second
+	if max < min {
+		max = min
+	}
+	return &backoff{
+		min:     min,
+		max:     max,
+		current: min,
+	}
+}
+
+// wait sleeps for the current delay, doubles it up to the maximum and returns early if the context is done.
+func (s *backoff) wait(ctx context.Context) {
+	timer := time.NewTimer(s.current)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	s.current *= 2
+	if s.current > s.max {
+		s.current = s.max
+	}
+}
+
+// reset sets the delay back to the minimum after a success.
+func (s *backoff) reset() {
+	s.current = s.min
+}
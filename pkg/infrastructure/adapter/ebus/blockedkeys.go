@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+)
+
+// blockedKeys holds the routing keys whose earliest message failed and is still pending, with the id of that message.
+// The later messages of a blocked key are left pending until the blocking message is acknowledged or dead-lettered,
+// so the messages of a user are not handled out of order while the failed one waits to be reclaimed.
+// A block expires after the given time, since the blocking message may be reclaimed and finished by another replica meanwhile.
+type blockedKeys struct {
+	mutex  sync.Mutex
+	ttl    time.Duration
+	blocks map[string]blockedKey
+}
+
+// blockedKey is the message that blocks a key and the time it failed.
+type blockedKey struct {
+	id        string
+	blockedAt time.Time
+}
+
+// newBlockedKeys creates a new empty *blockedKeys whose blocks expire after the given time.
+func newBlockedKeys(ttl time.Duration) *blockedKeys {
+	return &blockedKeys{
+		ttl:    ttl,
+		blocks: map[string]blockedKey{},
+	}
+}
+
+// blocked returns true if the given message of the key must wait for an earlier failed message of the key.
+func (b *blockedKeys) blocked(key string, id string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	block, ok := b.blocks[key]
+	if ok && time.Since(block.blockedAt) >= b.ttl {
+		delete(b.blocks, key)
+		return false
+	}
+	return ok && block.id != id
+}
+
+// block blocks the key by the given message, a blocked key keeps its earliest blocking message until the block expires.
+func (b *blockedKeys) block(key string, id string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if block, ok := b.blocks[key]; ok && block.id != id && time.Since(block.blockedAt) < b.ttl {
+		return
+	}
+	b.blocks[key] = blockedKey{
+		id:        id,
+		blockedAt: time.Now(),
+	}
+}
+
+// unblock releases the key if it is blocked by the given message.
+func (b *blockedKeys) unblock(key string, id string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.blocks[key].id == id {
+		delete(b.blocks, key)
+	}
+}
@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockedKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(b *blockedKeys) bool
+		want bool
+	}{
+		{"NotBlocked", func(b *blockedKeys) bool {
+			return b.blocked("user-1", "1")
+		}, false},
+		{"BlocksLaterMessage", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			return b.blocked("user-1", "2")
+		}, true},
+		{"DoesNotBlockBlockingMessage", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			return b.blocked("user-1", "1")
+		}, false},
+		{"DoesNotBlockOtherKey", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			return b.blocked("user-2", "2")
+		}, false},
+		{"KeepsEarliestBlockingMessage", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			b.block("user-1", "2")
+			return b.blocked("user-1", "2")
+		}, true},
+		{"UnblocksAfterBlockingMessageIsDone", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			b.unblock("user-1", "1")
+			return b.blocked("user-1", "2")
+		}, false},
+		{"OtherMessageDoesNotUnblock", func(b *blockedKeys) bool {
+			b.block("user-1", "1")
+			b.unblock("user-1", "2")
+			return b.blocked("user-1", "2")
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.run(newBlockedKeys(time.Minute)); got != tt.want {
+				t.Errorf("blocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockedKeys_Expiry(t *testing.T) {
+	b := newBlockedKeys(50 * time.Millisecond)
+	b.block("user-1", "1")
+	if !b.blocked("user-1", "2") {
+		t.Fatal("blocked() = false before the block expires, want true")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if b.blocked("user-1", "2") {
+		t.Error("blocked() = true after the block expires, want false")
+	}
+	b.block("user-1", "2")
+	if !b.blocked("user-1", "3") {
+		t.Error("blocked() = false after a new block of an expired key, want true")
+	}
+}
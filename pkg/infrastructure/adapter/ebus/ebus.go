@@ -2,26 +2,37 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
-	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
-	tredis "github.com/octoposprime/op-be-shared/tool/redis"
-	tserialize "github.com/octoposprime/op-be-shared/tool/serialize"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 	map_ebus "github.com/octoposprime/op-be-user/pkg/infrastructure/mapper/ebus"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
 )
 
+// messageField is the field of the stream entries that holds the json message.
+const messageField string = "message"
+
 type EBusAdapter struct {
-	redisClient *tredis.RedisClient
-	Log         func(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error)
+	backend Backend
+	pool    *workerPool
+	blocked *blockedKeys
+	Log     func(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error)
 }
 
-func NewEBusAdapter(redisClient *tredis.RedisClient) EBusAdapter {
+func NewEBusAdapter(backend Backend) EBusAdapter {
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
 	adapter := EBusAdapter{
-		redisClient: redisClient,
-		Log:         Log,
+		backend: backend,
+		pool:    newWorkerPool(ebusConfig.Workers, ebusConfig.QueueSize),
+		blocked: newBlockedKeys(time.Duration(ebusConfig.ClaimMinIdle) * time.Second),
+		Log:     Log,
 	}
 	return adapter
 }
@@ -37,15 +48,282 @@ func Log(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingR
 	return &pb_logging.LoggingResult{}, nil
 }
 
-// Listen listens to the redis messaging queue and calls the given callBack function for each received user.
-func (a EBusAdapter) Listen(ctx context.Context, channelName string, callBack func(channelName string, user me.User)) {
-	for {
-		result, err := a.redisClient.BLPop(ctx, 0*time.Second, channelName).Result()
+// Listen reads the stream of the channel in the consumer group of the service and calls the given callBack function for each received user command.
+// A message is acknowledged and removed from the stream only after the callBack succeeds, the unacknowledged messages of this or a crashed replica are reclaimed after the idle time.
+// The messages are handled on the worker pool of the adapter, the messages of the same user are handled one by one in order.
+// A new batch is not read until the current one is done, so a saturated pool slows the reading down.
+func (a EBusAdapter) Listen(ctx context.Context, channelName string, callBack func(ctx context.Context, channelName string, userCommand me.UserCommand) error) {
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
+	err := a.backend.CreateGroup(ctx, channelName)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Listen", channelName, err.Error()))
+	}
+	backoff := newBackoff(ebusConfig.BackoffMin, ebusConfig.BackoffMax)
+	for ctx.Err() == nil {
+		messages, err := a.backend.Read(ctx, channelName)
 		if err != nil {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Listen", channelName, err.Error()))
+			backoff.wait(ctx)
 			continue
 		}
-		inChannelName := result[0]
-		user := tserialize.SerializeFromJson[*pb.User](result[1])
-		go callBack(inChannelName, *map_ebus.NewUser(user).ToEntity())
+		if a.handleMessages(ctx, channelName, messages, callBack) {
+			backoff.reset()
+		} else {
+			backoff.wait(ctx)
+		}
+	}
+}
+
+// handleMessages handles the given batch on the worker pool and returns false if any message of it is not done.
+// The messages are routed by their user on every channel, so the commands of a user on different channels do not run at the same time.
+// Once a message of a user fails, the following messages of that user are left pending, in this and the later batches,
+// until the failed one is acknowledged or dead-lettered, so that they are not handled out of order.
+func (a EBusAdapter) handleMessages(ctx context.Context, channelName string, messages []Message, callBack func(ctx context.Context, channelName string, userCommand me.UserCommand) error) bool {
+	var batch sync.WaitGroup
+	var mutex sync.Mutex
+	succeeded := true
+	for _, message := range messages {
+		message := message
+		key := routingKey(channelName, message)
+		id := channelName + ":" + message.Id
+		batch.Add(1)
+		submitted := a.pool.submit(ctx, key, func() {
+			defer batch.Done()
+			if a.blocked.blocked(key, id) {
+				mutex.Lock()
+				succeeded = false
+				mutex.Unlock()
+				return
+			}
+			// The message is finished even if the listening is stopped meanwhile, so it is not handled with the listening context.
+			if !a.handleMessage(context.Background(), channelName, message, callBack) {
+				a.blocked.block(key, id)
+				mutex.Lock()
+				succeeded = false
+				mutex.Unlock()
+				return
+			}
+			a.blocked.unblock(key, id)
+		})
+		if !submitted {
+			// The listening is stopped, the rest of the batch stays pending for another consumer.
+			batch.Done()
+			mutex.Lock()
+			succeeded = false
+			mutex.Unlock()
+			break
+		}
+	}
+	batch.Wait()
+	return succeeded
+}
+
+// routingKey returns the id of the user of the given message, or the channel and the id of the message if it can not be decoded.
+func routingKey(channelName string, message Message) string {
+	payload, ok := message.Values[messageField].(string)
+	if !ok || !json.Valid([]byte(payload)) {
+		return channelName + ":" + message.Id
+	}
+	envelope, err := map_ebus.DecodeEnvelope(payload, channelName)
+	if err != nil {
+		return channelName + ":" + message.Id
+	}
+	userId := map_ebus.NewUserCommand(channelName, envelope).UserId()
+	if userId == (uuid.UUID{}) {
+		return channelName + ":" + message.Id
+	}
+	return userId.String()
+}
+
+// Close stops handling new messages and waits until the messages in progress are done or the context is done.
+func (a EBusAdapter) Close(ctx context.Context) error {
+	err := a.pool.close(ctx)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Close", "", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// handleMessage calls the callBack function for the given message and acknowledges it if the callBack succeeds.
+// A message that can not be deserialized or keeps failing is moved to the dead-letter stream of the channel.
+func (a EBusAdapter) handleMessage(ctx context.Context, channelName string, message Message, callBack func(ctx context.Context, channelName string, userCommand me.UserCommand) error) bool {
+	payload, ok := message.Values[messageField].(string)
+	if !ok || !json.Valid([]byte(payload)) {
+		// A malformed message can never succeed, it is dead-lettered at once.
+		return a.deadLetter(ctx, channelName, message, payload, "message is not a valid json", a.backend.DeliveryCount(ctx, channelName, message.Id))
+	}
+	envelope, err := map_ebus.DecodeEnvelope(payload, channelName)
+	if err != nil {
+		return a.deadLetter(ctx, channelName, message, payload, err.Error(), a.backend.DeliveryCount(ctx, channelName, message.Id))
+	}
+	if envelope.Id == "" {
+		// The messages published before the envelope are identified by their stream id.
+		envelope.Id = message.Id
+	}
+	dedupKey := "EVENT:" + tconfig.GetServiceConfigInstance().EBus.Group + ":" + envelope.Id
+	if processed, err := a.backend.HasKey(ctx, dedupKey); err == nil && processed {
+		a.backend.Ack(ctx, channelName, message.Id)
+		return true
+	}
+	callBackCtx := context.WithValue(context.Background(), smodel.QueryKeyUid, envelope.ActorId)
+	callBackCtx = context.WithValue(callBackCtx, mo.QueryKeyCorrelationId, envelope.CorrelationId)
+	callBackCtx = context.WithValue(callBackCtx, mo.QueryKeyIdempotencyKey, envelope.Id)
+	if err := callBack(callBackCtx, channelName, *map_ebus.NewUserCommand(channelName, envelope)); err != nil {
+		attempts := a.backend.DeliveryCount(ctx, channelName, message.Id)
+		if maxAttempts := tconfig.GetServiceConfigInstance().EBus.MaxAttempts; maxAttempts > 0 && attempts >= maxAttempts {
+			return a.deadLetter(ctx, channelName, message, payload, err.Error(), attempts)
+		}
+		return false
+	}
+	a.backend.SetKey(ctx, dedupKey, message.Id, time.Duration(tconfig.GetServiceConfigInstance().EBus.DedupTtl)*time.Second)
+	err = a.backend.Ack(ctx, channelName, message.Id)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Listen", channelName, err.Error()))
+	}
+	return true
+}
+
+// deadLetter moves the given message with the error to the dead-letter stream of the channel and removes it from the channel.
+// The password of a password reset is not kept in the dead-letter stream.
+func (a EBusAdapter) deadLetter(ctx context.Context, channelName string, message Message, payload string, errorText string, attempts int64) bool {
+	deadLetter := me.NewDeadLetter("", channelName, message.Id, map_ebus.RedactUserCommandMessage(channelName, payload), errorText, attempts, map_ebus.StreamIdTime(message.Id), time.Now())
+	_, err := a.backend.Add(ctx, channelName+map_ebus.DeadLetterStreamSuffix, map_ebus.NewDeadLetterValues(*deadLetter), 0)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Listen", channelName, err.Error()))
+		return false
+	}
+	go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeWARNING, "Listen", channelName, fmt.Sprintf("%v dead-lettered after %v attempts: %v", message.Id, attempts, errorText)))
+	a.backend.Ack(ctx, channelName, message.Id)
+	return true
+}
+
+// Publish appends the given user event in an envelope to the stream of its event type.
+func (a EBusAdapter) Publish(ctx context.Context, userEvent me.UserEvent) error {
+	channelName := map_ebus.UserEventChannelMap[userEvent.UserEventType]
+	payload, err := json.Marshal(map_ebus.NewUserEventFromEntity(userEvent).ToPb())
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Publish", userId, err.Error()))
+		return err
+	}
+	message, err := json.Marshal(map_ebus.NewEnvelope(userEvent.Id.String(), channelName, smodel.ServiceUser, userEvent.CorrelationId, userEvent.ActorId.String(), userEvent.OccurredAt, payload))
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Publish", userId, err.Error()))
+		return err
+	}
+	_, err = a.backend.Add(ctx, channelName, map[string]interface{}{messageField: string(message)}, 0)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "Publish", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// PublishUserCommandResult appends the given result in an envelope to the reply stream of the channel of its command.
+func (a EBusAdapter) PublishUserCommandResult(ctx context.Context, userCommandResult me.UserCommandResult) error {
+	channelName := userCommandResult.ChannelName + map_ebus.ReplyStreamSuffix
+	payload, err := json.Marshal(map_ebus.NewUserCommandResultFromEntity(userCommandResult).ToPb())
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "PublishUserCommandResult", userId, err.Error()))
+		return err
+	}
+	actorId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	correlationId, _ := ctx.Value(mo.QueryKeyCorrelationId).(string)
+	message, err := json.Marshal(map_ebus.NewEnvelope(uuid.New().String(), channelName, smodel.ServiceUser, correlationId, actorId, userCommandResult.OccurredAt, payload))
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "PublishUserCommandResult", actorId, err.Error()))
+		return err
+	}
+	_, err = a.backend.Add(ctx, channelName, map[string]interface{}{messageField: string(message)}, tconfig.GetServiceConfigInstance().EBus.ReplyMaxLen)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "PublishUserCommandResult", actorId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter, oldest first.
+func (a EBusAdapter) GetDeadLetters(ctx context.Context, deadLetterFilter me.DeadLetterFilter) (me.DeadLetters, error) {
+	stream := deadLetterFilter.ChannelName + map_ebus.DeadLetterStreamSuffix
+	totalRows, err := a.backend.Len(ctx, stream)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDeadLetters", userId, err.Error()))
+		return me.DeadLetters{}, err
+	}
+	var messages []Message
+	if len(deadLetterFilter.Ids) > 0 {
+		for _, id := range deadLetterFilter.Ids {
+			idMessages, err := a.backend.Range(ctx, stream, id, id, 0)
+			if err != nil {
+				userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+				go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDeadLetters", userId, err.Error()))
+				return me.DeadLetters{}, err
+			}
+			messages = append(messages, idMessages...)
+		}
+	} else {
+		start := "-"
+		if deadLetterFilter.AfterId != "" {
+			start = "(" + deadLetterFilter.AfterId
+		}
+		messages, err = a.backend.Range(ctx, stream, start, "+", int64(deadLetterFilter.Limit))
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDeadLetters", userId, err.Error()))
+			return me.DeadLetters{}, err
+		}
+	}
+	deadLetters := make([]me.DeadLetter, len(messages))
+	for i, message := range messages {
+		deadLetters[i] = *map_ebus.NewDeadLetter(deadLetterFilter.ChannelName, message.Id, message.Values).ToEntity()
+		// The entries dead-lettered before the redaction may still hold a password.
+		deadLetters[i].Message = map_ebus.RedactUserCommandMessage(deadLetterFilter.ChannelName, deadLetters[i].Message)
+	}
+	return me.DeadLetters{
+		DeadLetters: deadLetters,
+		TotalRows:   totalRows,
+	}, nil
+}
+
+// ReplayDeadLetter adds the given dead-lettered message back to its channel and removes it from the dead-letter stream.
+func (a EBusAdapter) ReplayDeadLetter(ctx context.Context, deadLetter me.DeadLetter) error {
+	_, err := a.backend.Add(ctx, deadLetter.ChannelName, map[string]interface{}{messageField: deadLetter.Message}, 0)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReplayDeadLetter", userId, err.Error()))
+		return err
+	}
+	_, err = a.backend.Delete(ctx, deadLetter.ChannelName+map_ebus.DeadLetterStreamSuffix, deadLetter.Id)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReplayDeadLetter", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// DeleteDeadLetters hard-deletes the given dead-lettered messages of the channel, or all of them if no id is given.
+func (a EBusAdapter) DeleteDeadLetters(ctx context.Context, channelName string, ids []string) (int64, error) {
+	stream := channelName + map_ebus.DeadLetterStreamSuffix
+	var count int64
+	var err error
+	if len(ids) > 0 {
+		count, err = a.backend.Delete(ctx, stream, ids...)
+	} else {
+		count, err = a.backend.Len(ctx, stream)
+		if err == nil {
+			err = a.backend.Drop(ctx, stream)
+		}
+	}
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteDeadLetters", userId, err.Error()))
+		return 0, err
 	}
+	return count, nil
 }
@@ -0,0 +1,350 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// memoryPending is the delivery state of a read but not acknowledged message.
+type memoryPending struct {
+	deliveries  int64
+	deliveredAt time.Time
+}
+
+// memoryStream is a stream of the in-memory backend with the state of the consumer group of the service.
+type memoryStream struct {
+	messages  []Message
+	delivered string
+	pending   map[string]*memoryPending
+	expiresAt time.Time
+}
+
+// memoryKey is a stored key of the in-memory backend.
+type memoryKey struct {
+	value     string
+	expiresAt time.Time
+}
+
+// memoryBackend is the in-process event bus backend, it needs no external service.
+// It keeps the streams of one process only, so it is meant for the local mode and the tests.
+type memoryBackend struct {
+	mutex   sync.Mutex
+	streams map[string]*memoryStream
+	keys    map[string]memoryKey
+	lastMs  int64
+	lastSeq int64
+	added   chan struct{} // added is closed and renewed on every new entry to wake the blocked readers up.
+}
+
+// NewMemoryBackend creates a new in-process Backend.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{
+		streams: map[string]*memoryStream{},
+		keys:    map[string]memoryKey{},
+		added:   make(chan struct{}),
+	}
+}
+
+// CreateGroup creates the consumer group of the service on the stream, the stream is created if it does not exist.
+func (b *memoryBackend) CreateGroup(ctx context.Context, stream string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.stream(stream)
+	return nil
+}
+
+// Read returns the pending messages of the stream that are idle longer than the claim idle time, otherwise the new ones.
+func (b *memoryBackend) Read(ctx context.Context, stream string) ([]Message, error) {
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
+	block := time.NewTimer(time.Duration(ebusConfig.Block) * time.Second)
+	defer block.Stop()
+	for {
+		b.mutex.Lock()
+		messages := b.claim(stream, time.Duration(ebusConfig.ClaimMinIdle)*time.Second, ebusConfig.BatchSize)
+		if len(messages) == 0 {
+			messages = b.readNew(stream, ebusConfig.BatchSize)
+		}
+		added := b.added
+		b.mutex.Unlock()
+		if len(messages) > 0 {
+			return messages, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-block.C:
+			return nil, nil
+		case <-added:
+		}
+	}
+}
+
+// claim delivers again the pending messages of the stream that are idle longer than minIdle.
+func (b *memoryBackend) claim(stream string, minIdle time.Duration, count int64) []Message {
+	s := b.stream(stream)
+	now := time.Now()
+	messages := []Message{}
+	for _, message := range s.messages {
+		if count > 0 && int64(len(messages)) >= count {
+			break
+		}
+		pending, ok := s.pending[message.Id]
+		if !ok || now.Sub(pending.deliveredAt) < minIdle {
+			continue
+		}
+		pending.deliveries++
+		pending.deliveredAt = now
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// readNew delivers the messages of the stream that are not delivered yet.
+func (b *memoryBackend) readNew(stream string, count int64) []Message {
+	s := b.stream(stream)
+	now := time.Now()
+	messages := []Message{}
+	for _, message := range s.messages {
+		if count > 0 && int64(len(messages)) >= count {
+			break
+		}
+		if s.delivered != "" && compareIds(message.Id, s.delivered) <= 0 {
+			continue
+		}
+		s.pending[message.Id] = &memoryPending{
+			deliveries:  1,
+			deliveredAt: now,
+		}
+		s.delivered = message.Id
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// DeliveryCount returns the number of the deliveries of the given pending message.
+func (b *memoryBackend) DeliveryCount(ctx context.Context, stream string, id string) int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	pending, ok := b.stream(stream).pending[id]
+	if !ok {
+		return 1
+	}
+	return pending.deliveries
+}
+
+// Ack acknowledges the given messages so that they are not delivered again and removes them from the stream.
+func (b *memoryBackend) Ack(ctx context.Context, stream string, ids ...string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s := b.stream(stream)
+	acked := map[string]bool{}
+	for _, id := range ids {
+		acked[id] = true
+		delete(s.pending, id)
+	}
+	messages := s.messages[:0]
+	for _, message := range s.messages {
+		if !acked[message.Id] {
+			messages = append(messages, message)
+		}
+	}
+	s.messages = messages
+	return nil
+}
+
+// Add appends an entry with the given values to the stream and returns its id.
+// The values are stored as strings as the redis streams do.
+func (b *memoryBackend) Add(ctx context.Context, stream string, values map[string]interface{}, maxLen int64) (string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s := b.stream(stream)
+	message := Message{
+		Id:     b.nextId(),
+		Values: make(map[string]interface{}, len(values)),
+	}
+	for field, value := range values {
+		message.Values[field] = fmt.Sprint(value)
+	}
+	s.messages = append(s.messages, message)
+	if maxLen > 0 && int64(len(s.messages)) > maxLen {
+		for _, trimmed := range s.messages[:int64(len(s.messages))-maxLen] {
+			delete(s.pending, trimmed.Id)
+		}
+		s.messages = s.messages[int64(len(s.messages))-maxLen:]
+	}
+	close(b.added)
+	b.added = make(chan struct{})
+	return message.Id, nil
+}
+
+// Delete removes the given entries from the stream and returns the number of the removed ones.
+func (b *memoryBackend) Delete(ctx context.Context, stream string, ids ...string) (int64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s := b.stream(stream)
+	deleted := map[string]bool{}
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	var count int64
+	messages := s.messages[:0]
+	for _, message := range s.messages {
+		if deleted[message.Id] {
+			delete(s.pending, message.Id)
+			count++
+			continue
+		}
+		messages = append(messages, message)
+	}
+	s.messages = messages
+	return count, nil
+}
+
+// Len returns the number of the entries of the stream.
+func (b *memoryBackend) Len(ctx context.Context, stream string) (int64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return int64(len(b.stream(stream).messages)), nil
+}
+
+// Range returns the entries of the stream between the start and the end ids, oldest first, at most count if it is not 0.
+func (b *memoryBackend) Range(ctx context.Context, stream string, start string, end string, count int64) ([]Message, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	messages := []Message{}
+	for _, message := range b.stream(stream).messages {
+		if count > 0 && int64(len(messages)) >= count {
+			break
+		}
+		if !afterStart(message.Id, start) || !beforeEnd(message.Id, end) {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// Drop deletes the stream with all of its entries.
+func (b *memoryBackend) Drop(ctx context.Context, stream string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.streams, stream)
+	return nil
+}
+
+// Expire deletes the stream after the ttl unless it is extended again.
+func (b *memoryBackend) Expire(ctx context.Context, stream string, ttl time.Duration) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.stream(stream).expiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+// SetKey stores the given key for the ttl.
+func (b *memoryBackend) SetKey(ctx context.Context, key string, value string, ttl time.Duration) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	expiresAt := time.Time{}
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	b.keys[key] = memoryKey{
+		value:     value,
+		expiresAt: expiresAt,
+	}
+	return nil
+}
+
+// HasKey returns true if the given key is stored.
+func (b *memoryBackend) HasKey(ctx context.Context, key string) (bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	storedKey, ok := b.keys[key]
+	if ok && !storedKey.expiresAt.IsZero() && time.Now().After(storedKey.expiresAt) {
+		delete(b.keys, key)
+		return false, nil
+	}
+	return ok, nil
+}
+
+// stream returns the stream of the given name, creating it if it does not exist or is expired.
+// The mutex must be held by the caller.
+func (b *memoryBackend) stream(name string) *memoryStream {
+	s, ok := b.streams[name]
+	if !ok || (!s.expiresAt.IsZero() && time.Now().After(s.expiresAt)) {
+		s = &memoryStream{
+			pending: map[string]*memoryPending{},
+		}
+		b.streams[name] = s
+	}
+	return s
+}
+
+// nextId returns a new entry id that is greater than all of the previous ones, in the "<ms>-<seq>" form of the redis streams.
+// The mutex must be held by the caller.
+func (b *memoryBackend) nextId() string {
+	ms := time.Now().UnixMilli()
+	if ms <= b.lastMs {
+		b.lastSeq++
+	} else {
+		b.lastMs = ms
+		b.lastSeq = 0
+	}
+	return fmt.Sprintf("%d-%d", b.lastMs, b.lastSeq)
+}
+
+// afterStart returns true if the given id is not before the start of a range.
+func afterStart(id string, start string) bool {
+	if start == "-" || start == "" {
+		return true
+	}
+	if strings.HasPrefix(start, "(") {
+		return compareIds(id, strings.TrimPrefix(start, "(")) > 0
+	}
+	return compareIds(id, start) >= 0
+}
+
+// beforeEnd returns true if the given id is not after the end of a range.
+func beforeEnd(id string, end string) bool {
+	if end == "+" || end == "" {
+		return true
+	}
+	if strings.HasPrefix(end, "(") {
+		return compareIds(id, strings.TrimPrefix(end, "(")) < 0
+	}
+	return compareIds(id, end) <= 0
+}
+
+// compareIds compares the given stream entry ids by their time and sequence parts.
+func compareIds(a string, b string) int {
+	aMs, aSeq := splitId(a)
+	bMs, bSeq := splitId(b)
+	switch {
+	case aMs < bMs:
+		return -1
+	case aMs > bMs:
+		return 1
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	}
+	return 0
+}
+
+// splitId returns the time and the sequence parts of the given stream entry id, a missing sequence is 0.
+func splitId(id string) (int64, int64) {
+	parts := strings.SplitN(id, "-", 2)
+	ms, _ := strconv.ParseInt(parts[0], 10, 64)
+	var seq int64
+	if len(parts) == 2 {
+		seq, _ = strconv.ParseInt(parts[1], 10, 64)
+	}
+	return ms, seq
+}
@@ -0,0 +1,11 @@
+package infrastructure
+
+import (
+	"testing"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	testBackendConformance(t, func(t *testing.T) Backend {
+		return NewMemoryBackend()
+	})
+}
@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	map_ebus "github.com/octoposprime/op-be-user/pkg/infrastructure/mapper/ebus"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+// ServeUserQueries answers the user filter queries of the channel with the users returned by the given callBack function.
+// A query whose filter can not be deserialized is answered with an error instead of being run without a filter.
+// The limit of the filter is capped to the configured maximum so that a query can not read the whole table at once.
+func (a EBusAdapter) ServeUserQueries(ctx context.Context, channelName string, callBack func(ctx context.Context, userFilter me.UserFilter) (me.Users, error)) {
+	a.serveQueries(ctx, channelName, func(ctx context.Context, query map_ebus.Envelope) (interface{}, error) {
+		var userFilter pb.UserFilter
+		if err := json.Unmarshal(query.Payload, &userFilter); err != nil {
+			return nil, mo.ErrorUserQueryIsNotValid
+		}
+		filter := *map_ebus.NewUserFilter(&userFilter).ToEntity()
+		filter.Limit = queryLimit(filter.Limit)
+		users, err := callBack(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Users{
+			Users:         map_ebus.NewUserFromEntities(users.Users).ToPbs().Users,
+			TotalRows:     users.TotalRows,
+			NextPageToken: users.NextPageToken,
+		}, nil
+	})
+}
+
+// queryLimit returns the given limit of a query capped to the configured maximum, a query without a limit gets the maximum.
+func queryLimit(limit int) int {
+	maxLimit := tconfig.GetServiceConfigInstance().EBus.QueryMaxLimit
+	if maxLimit <= 0 {
+		maxLimit = 1000
+	}
+	if limit <= 0 || limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
+
+// serveQueries reads the query stream of the channel in the consumer group of the service and sends the reply of each query to its reply stream.
+// The queries are answered on the worker pool, a query that is expired is dropped since its requester does not wait for it anymore.
+// The reply carries the correlation id of the query, or the id of the query if it has none, so that the requester can match it.
+func (a EBusAdapter) serveQueries(ctx context.Context, channelName string, answer func(ctx context.Context, query map_ebus.Envelope) (interface{}, error)) {
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
+	err := a.backend.CreateGroup(ctx, channelName)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ServeQueries", channelName, err.Error()))
+	}
+	backoff := newBackoff(ebusConfig.BackoffMin, ebusConfig.BackoffMax)
+	for ctx.Err() == nil {
+		messages, err := a.backend.Read(ctx, channelName)
+		if err != nil {
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ServeQueries", channelName, err.Error()))
+			backoff.wait(ctx)
+			continue
+		}
+		backoff.reset()
+		var batch sync.WaitGroup
+		for _, message := range messages {
+			message := message
+			batch.Add(1)
+			submitted := a.pool.submit(ctx, message.Id, func() {
+				defer batch.Done()
+				a.answerQuery(channelName, message, answer)
+			})
+			if !submitted {
+				batch.Done()
+				break
+			}
+		}
+		batch.Wait()
+	}
+}
+
+// answerQuery calls the answer function for the given query message, sends its reply and acknowledges the message.
+// A query is answered once, a failing answer is sent back as the error of the reply instead of being retried.
+// A query that is not valid is also moved to the dead-letter stream of the channel after its reply is sent.
+func (a EBusAdapter) answerQuery(channelName string, message Message, answer func(ctx context.Context, query map_ebus.Envelope) (interface{}, error)) {
+	ctx := context.Background()
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
+	defer a.backend.Ack(ctx, channelName, message.Id)
+	payload, _ := message.Values[messageField].(string)
+	query, err := map_ebus.DecodeEnvelope(payload, channelName)
+	if err != nil {
+		a.deadLetter(ctx, channelName, message, payload, err.Error(), a.backend.DeliveryCount(ctx, channelName, message.Id))
+		return
+	}
+	if query.ReplyTo == "" {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ServeQueries", channelName, message.Id+" has no reply channel"))
+		return
+	}
+	expiresAt := time.Now().Add(time.Duration(ebusConfig.QueryTimeout) * time.Second)
+	if query.ExpiresAt != nil {
+		expiresAt = *query.ExpiresAt
+	}
+	if !time.Now().Before(expiresAt) {
+		return
+	}
+	queryCtx, cancel := context.WithDeadline(ctx, expiresAt)
+	defer cancel()
+	queryCtx = context.WithValue(queryCtx, smodel.QueryKeyUid, query.ActorId)
+	queryCtx = context.WithValue(queryCtx, mo.QueryKeyCorrelationId, query.CorrelationId)
+	correlationId := query.CorrelationId
+	if correlationId == "" {
+		correlationId = query.Id
+	}
+	reply := map_ebus.NewEnvelope(uuid.New().String(), channelName+map_ebus.ReplyStreamSuffix, smodel.ServiceUser, correlationId, query.ActorId, time.Now(), nil)
+	result, err := answer(queryCtx, query)
+	if err == nil {
+		reply.Payload, err = json.Marshal(result)
+	}
+	if err != nil {
+		reply.Error = err.Error()
+		if errors.Is(err, mo.ErrorUserQueryIsNotValid) {
+			defer a.deadLetter(ctx, channelName, message, payload, err.Error(), a.backend.DeliveryCount(ctx, channelName, message.Id))
+		}
+	}
+	replyMessage, err := json.Marshal(reply)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ServeQueries", channelName, err.Error()))
+		return
+	}
+	_, err = a.backend.Add(ctx, query.ReplyTo, map[string]interface{}{messageField: string(replyMessage)}, ebusConfig.ReplyMaxLen)
+	if err != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ServeQueries", channelName, err.Error()))
+		return
+	}
+	// The reply stream of a requester that has gone away is not kept forever.
+	if replyTtl := time.Duration(ebusConfig.QueryReplyTtl) * time.Second; replyTtl > 0 {
+		a.backend.Expire(ctx, query.ReplyTo, replyTtl)
+	}
+}
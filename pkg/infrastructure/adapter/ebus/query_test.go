@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"testing"
+
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+)
+
+func TestQueryLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxLimit int
+		limit    int
+		want     int
+	}{
+		{name: "Limit Under Maximum", maxLimit: 100, limit: 10, want: 10},
+		{name: "Limit Over Maximum", maxLimit: 100, limit: 1000, want: 100},
+		{name: "No Limit", maxLimit: 100, limit: 0, want: 100},
+		{name: "Negative Limit", maxLimit: 100, limit: -1, want: 100},
+		{name: "No Maximum", maxLimit: 0, limit: 5000, want: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tconfig.ServiceConfigInstance = &tconfig.ServiceConfig{}
+			tconfig.ServiceConfigInstance.EBus.QueryMaxLimit = tt.maxLimit
+			if got := queryLimit(tt.limit); got != tt.want {
+				t.Errorf("queryLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tredis "github.com/octoposprime/op-be-shared/tool/redis"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisBackend is the event bus backend on the redis streams.
+type redisBackend struct {
+	redisClient *tredis.RedisClient
+}
+
+// NewRedisBackend creates a new Backend on the redis streams of the given client.
+func NewRedisBackend(redisClient *tredis.RedisClient) Backend {
+	return redisBackend{
+		redisClient: redisClient,
+	}
+}
+
+// CreateGroup creates the consumer group of the service on the stream, the stream is created if it does not exist.
+func (b redisBackend) CreateGroup(ctx context.Context, stream string) error {
+	err := b.redisClient.XGroupCreateMkStream(ctx, stream, tconfig.GetServiceConfigInstance().EBus.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// Read returns the reclaimed idle messages of the stream if there are any, otherwise the new ones.
+func (b redisBackend) Read(ctx context.Context, stream string) ([]Message, error) {
+	ebusConfig := tconfig.GetServiceConfigInstance().EBus
+	claimed, _, err := b.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    ebusConfig.Group,
+		Consumer: ebusConfig.Consumer,
+		MinIdle:  time.Duration(ebusConfig.ClaimMinIdle) * time.Second,
+		Start:    "0-0",
+		Count:    ebusConfig.BatchSize,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if len(claimed) > 0 {
+		return toMessages(claimed), nil
+	}
+	streams, err := b.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    ebusConfig.Group,
+		Consumer: ebusConfig.Consumer,
+		Streams:  []string{stream, ">"},
+		Count:    ebusConfig.BatchSize,
+		Block:    time.Duration(ebusConfig.Block) * time.Second,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	messages := []Message{}
+	for _, stream := range streams {
+		messages = append(messages, toMessages(stream.Messages)...)
+	}
+	return messages, nil
+}
+
+// DeliveryCount returns the number of the deliveries of the given pending message.
+func (b redisBackend) DeliveryCount(ctx context.Context, stream string, id string) int64 {
+	pendings, err := b.redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  tconfig.GetServiceConfigInstance().EBus.Group,
+		Start:  id,
+		End:    id,
+		Count:  1,
+	}).Result()
+	if err != nil || len(pendings) == 0 {
+		return 1
+	}
+	return pendings[0].RetryCount
+}
+
+// Ack acknowledges the given messages so that they are not delivered again and removes them from the stream.
+func (b redisBackend) Ack(ctx context.Context, stream string, ids ...string) error {
+	_, err := b.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, stream, tconfig.GetServiceConfigInstance().EBus.Group, ids...)
+		pipe.XDel(ctx, stream, ids...)
+		return nil
+	})
+	return err
+}
+
+// Add appends an entry with the given values to the stream and returns its id.
+func (b redisBackend) Add(ctx context.Context, stream string, values map[string]interface{}, maxLen int64) (string, error) {
+	return b.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+}
+
+// Delete removes the given entries from the stream and returns the number of the removed ones.
+func (b redisBackend) Delete(ctx context.Context, stream string, ids ...string) (int64, error) {
+	return b.redisClient.XDel(ctx, stream, ids...).Result()
+}
+
+// Len returns the number of the entries of the stream.
+func (b redisBackend) Len(ctx context.Context, stream string) (int64, error) {
+	return b.redisClient.XLen(ctx, stream).Result()
+}
+
+// Range returns the entries of the stream between the start and the end ids, oldest first, at most count if it is not 0.
+func (b redisBackend) Range(ctx context.Context, stream string, start string, end string, count int64) ([]Message, error) {
+	var messages []redis.XMessage
+	var err error
+	if count != 0 {
+		messages, err = b.redisClient.XRangeN(ctx, stream, start, end, count).Result()
+	} else {
+		messages, err = b.redisClient.XRange(ctx, stream, start, end).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toMessages(messages), nil
+}
+
+// Drop deletes the stream with all of its entries.
+func (b redisBackend) Drop(ctx context.Context, stream string) error {
+	return b.redisClient.Del(ctx, stream).Err()
+}
+
+// Expire deletes the stream after the ttl unless it is extended again.
+func (b redisBackend) Expire(ctx context.Context, stream string, ttl time.Duration) error {
+	return b.redisClient.Expire(ctx, stream, ttl).Err()
+}
+
+// SetKey stores the given key for the ttl.
+func (b redisBackend) SetKey(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return b.redisClient.Set(ctx, key, value, ttl).Err()
+}
+
+// HasKey returns true if the given key is stored.
+func (b redisBackend) HasKey(ctx context.Context, key string) (bool, error) {
+	count, err := b.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// toMessages converts the given redis stream entries to the backend messages.
+func toMessages(xMessages []redis.XMessage) []Message {
+	messages := make([]Message, len(xMessages))
+	for i, xMessage := range xMessages {
+		messages[i] = Message{
+			Id:     xMessage.ID,
+			Values: xMessage.Values,
+		}
+	}
+	return messages
+}
@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"os"
+	"testing"
+
+	tredis "github.com/octoposprime/op-be-shared/tool/redis"
+)
+
+// TestRedisBackend runs on the redis given by the EBUS_TEST_REDIS_HOST and EBUS_TEST_REDIS_PORT variables, it is skipped without them.
+func TestRedisBackend(t *testing.T) {
+	host := os.Getenv("EBUS_TEST_REDIS_HOST")
+	if host == "" {
+		t.Skip("EBUS_TEST_REDIS_HOST is not set")
+	}
+	redisClient := tredis.NewRedisClient(host, os.Getenv("EBUS_TEST_REDIS_PORT"), os.Getenv("EBUS_TEST_REDIS_PASSWORD"), 0)
+	testBackendConformance(t, func(t *testing.T) Backend {
+		return NewRedisBackend(redisClient)
+	})
+}
@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+)
+
+// workerPool runs the jobs on a fixed number of workers.
+// The jobs of the same key always run on the same worker, so they run one by one in the order they are submitted.
+type workerPool struct {
+	queues []chan func()
+	mutex  sync.RWMutex
+	closed bool
+	done   sync.WaitGroup
+}
+
+// newWorkerPool creates a new *workerPool with the given number of workers and the queue size of each worker.
+func newWorkerPool(workers int, queueSize int) *workerPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	pool := &workerPool{
+		queues: make([]chan func(), workers),
+	}
+	for i := range pool.queues {
+		pool.queues[i] = make(chan func(), queueSize)
+		pool.done.Add(1)
+		go pool.work(pool.queues[i])
+	}
+	return pool
+}
+
+// work runs the jobs of the given queue until it is closed and drained.
+func (s *workerPool) work(queue chan func()) {
+	defer s.done.Done()
+	for job := range queue {
+		job()
+	}
+}
+
+// submit queues the given job on the worker of the key.
+// It blocks while the queue of the worker is full and returns false if the context is done or the pool is closed.
+func (s *workerPool) submit(ctx context.Context, key string, job func()) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.closed {
+		return false
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	select {
+	case s.queues[hash.Sum32()%uint32(len(s.queues))] <- job:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// close stops accepting new jobs and waits until the queued ones are done or the context is done.
+func (s *workerPool) close(ctx context.Context) error {
+	s.mutex.Lock()
+	if !s.closed {
+		s.closed = true
+		for _, queue := range s.queues {
+			close(queue)
+		}
+	}
+	s.mutex.Unlock()
+	drained := make(chan struct{})
+	go func() {
+		s.done.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
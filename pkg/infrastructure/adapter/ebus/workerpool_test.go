@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_KeepsOrderOfKey(t *testing.T) {
+	pool := newWorkerPool(4, 2)
+	var mutex sync.Mutex
+	received := map[string][]int{}
+	for i := 0; i < 50; i++ {
+		i := i
+		key := "user-" + strconv.Itoa(i%3)
+		if !pool.submit(context.Background(), key, func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			received[key] = append(received[key], i)
+		}) {
+			t.Fatal("submit() = false, want true")
+		}
+	}
+	if err := pool.close(context.Background()); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+	for key, indexes := range received {
+		for j := 1; j < len(indexes); j++ {
+			if indexes[j] < indexes[j-1] {
+				t.Errorf("the jobs of %v run in the order %v", key, indexes)
+				break
+			}
+		}
+	}
+}
+
+func TestWorkerPool_BlocksWhenQueueIsFull(t *testing.T) {
+	pool := newWorkerPool(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.submit(context.Background(), "user-1", func() {
+		close(started)
+		<-release
+	})
+	<-started
+	if !pool.submit(context.Background(), "user-1", func() {}) {
+		t.Fatal("submit() = false while the queue has room, want true")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if pool.submit(ctx, "user-1", func() {}) {
+		t.Error("submit() = true while the queue is full, want false after the context is done")
+	}
+	close(release)
+	if err := pool.close(context.Background()); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+}
+
+func TestWorkerPool_DrainsOnClose(t *testing.T) {
+	pool := newWorkerPool(2, 10)
+	var mutex sync.Mutex
+	done := 0
+	for i := 0; i < 10; i++ {
+		pool.submit(context.Background(), strconv.Itoa(i), func() {
+			time.Sleep(10 * time.Millisecond)
+			mutex.Lock()
+			defer mutex.Unlock()
+			done++
+		})
+	}
+	if err := pool.close(context.Background()); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if done != 10 {
+		t.Errorf("%v jobs are done on close, want 10", done)
+	}
+	if pool.submit(context.Background(), "0", func() {}) {
+		t.Error("submit() = true after close, want false")
+	}
+}
+
+func TestWorkerPool_CloseTimesOut(t *testing.T) {
+	pool := newWorkerPool(1, 1)
+	release := make(chan struct{})
+	defer close(release)
+	pool.submit(context.Background(), "user-1", func() {
+		<-release
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := pool.close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("close() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
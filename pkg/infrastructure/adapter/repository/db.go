@@ -2,14 +2,19 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	smodel "github.com/octoposprime/op-be-shared/pkg/model"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
 	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
 	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 	map_repo "github.com/octoposprime/op-be-user/pkg/infrastructure/mapper/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DbAdapter struct {
@@ -31,6 +36,26 @@ func NewDbAdapter(dbClient *tgorm.GormClient) DbAdapter {
 	if err != nil {
 		panic(err)
 	}
+	err = dbClient.DbClient.AutoMigrate(&map_repo.UserStatusHistory{})
+	if err != nil {
+		panic(err)
+	}
+	err = dbClient.DbClient.AutoMigrate(&map_repo.UserLoginHistory{})
+	if err != nil {
+		panic(err)
+	}
+	err = dbClient.DbClient.AutoMigrate(&map_repo.OutboxEvent{})
+	if err != nil {
+		panic(err)
+	}
+	err = dbClient.DbClient.AutoMigrate(&map_repo.AuditEntry{})
+	if err != nil {
+		panic(err)
+	}
+	err = dbClient.DbClient.AutoMigrate(&map_repo.UserReadAccess{})
+	if err != nil {
+		panic(err)
+	}
 
 	return adapter
 }
@@ -40,14 +65,187 @@ func (a *DbAdapter) SetLogger(LoggerFunc func(ctx context.Context, logData *pb_l
 	a.Log = LoggerFunc
 }
 
+// auditLockKey is the key of the transaction level advisory lock that serializes the writes of the audit trail.
+const auditLockKey = 7305624101
+
+// txKey is the context key of the running database transaction.
+type txKey struct{}
+
+// RunInTransaction calls fn in a database transaction, the adapter calls made with the context given to fn join the transaction.
+func (a DbAdapter) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return a.DbClient.Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbClient returns the running transaction of the context or the db client if there is none.
+func (a DbAdapter) dbClient(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return a.DbClient
+}
+
 // GetUsersByFilter returns the users that match the given filter.
+// The page token of the filter continues after the last user of the previous page and is used instead of the offset,
+// the users are always ordered by their ids after the sort of the filter so that the pages are stable.
 func (a DbAdapter) GetUsersByFilter(ctx context.Context, userFilter me.UserFilter) (me.Users, error) {
 	var usersDbMapper map_repo.Users
+	qry := a.userFilterQuery(ctx, userFilter)
+	totalRows := a.countUsers(ctx, qry, userFilter.CountMode)
+	sortField, sortDesc := userFilterSort(userFilter)
+	if userFilter.PageToken != "" {
+		userPageToken, err := map_repo.ParseUserPageToken(userFilter.PageToken)
+		if err == nil && (userPageToken.SortField != sortField || userPageToken.SortDesc != sortDesc) {
+			err = mo.ErrorUserPageTokenIsNotValid
+		}
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, err.Error()))
+			return me.Users{}, err
+		}
+		where, values := userPageToken.Where()
+		qry = qry.Where(where, values...)
+	} else if userFilter.Offset != 0 {
+		qry = qry.Offset(userFilter.Offset)
+	}
+	if userFilter.Limit != 0 {
+		qry = qry.Limit(userFilter.Limit)
+	}
+	result := qry.Order(userFilterOrder(sortField, sortDesc)).Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	users := me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: totalRows,
+	}
+	if userFilter.Limit != 0 && len(usersDbMapper) == userFilter.Limit {
+		users.NextPageToken = map_repo.NewUserPageToken(*usersDbMapper[len(usersDbMapper)-1], sortField, sortDesc).String()
+	}
+	return users, nil
+}
+
+// countUsers returns the total number of the users of the given query in the given count mode, it is -1 if the users are not counted.
+// The estimation falls back to the exact count if the plan of the query cannot be read.
+func (a DbAdapter) countUsers(ctx context.Context, qry *gorm.DB, countMode mo.UserCountMode) int64 {
+	if countMode == mo.UserCountModeSKIP {
+		return -1
+	}
+	if countMode == mo.UserCountModeESTIMATED {
+		totalRows, err := a.estimateUsers(ctx, qry)
+		if err == nil {
+			return totalRows
+		}
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, err.Error()))
+	}
+	var totalRows int64
+	result := qry.Model(&map_repo.User{}).Count(&totalRows)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, result.Error.Error()))
+		totalRows = 0
+	}
+	return totalRows
+}
+
+// estimateUsers returns the number of the rows the planner expects for the given query from the table statistics without running it.
+func (a DbAdapter) estimateUsers(ctx context.Context, qry *gorm.DB) (int64, error) {
+	stmt := qry.Session(&gorm.Session{DryRun: true}).Model(&map_repo.User{}).Select("id").Find(&map_repo.Users{}).Statement
+	var plan []byte
+	err := a.dbClient(ctx).Statement.ConnPool.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Scan(&plan)
+	if err != nil {
+		return 0, err
+	}
+	var plans []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, nil
+	}
+	return int64(plans[0].Plan.PlanRows), nil
+}
+
+// StreamUsersByFilter calls the given function for each user that matches the given filter.
+// The users are read one by one from the database cursor, so the memory use does not grow with the number of the users.
+// The users are ordered by their ids after the sort of the filter, the stream stops at the first error of the function.
+func (a DbAdapter) StreamUsersByFilter(ctx context.Context, userFilter me.UserFilter, fn func(user me.User) error) error {
+	qry := a.userFilterQuery(ctx, userFilter).Model(&map_repo.User{})
+	if userFilter.Limit != 0 {
+		qry = qry.Limit(userFilter.Limit)
+	}
+	if userFilter.Offset != 0 {
+		qry = qry.Offset(userFilter.Offset)
+	}
+	rows, err := qry.Order(userFilterOrder(userFilterSort(userFilter))).Rows()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "StreamUsersByFilter", userId, err.Error()))
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userDbMapper map_repo.User
+		if err := qry.ScanRows(rows, &userDbMapper); err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "StreamUsersByFilter", userId, err.Error()))
+			return err
+		}
+		if err := fn(*userDbMapper.ToEntity()); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "StreamUsersByFilter", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// GetUsersForUpdate returns the users of the given ids and locks their rows with SELECT ... FOR UPDATE until the end of the transaction.
+// The rows are locked in the order of their ids so that the concurrent transactions do not deadlock, it must be called in a transaction.
+func (a DbAdapter) GetUsersForUpdate(ctx context.Context, userIds []uuid.UUID) (me.Users, error) {
+	var usersDbMapper map_repo.Users
+	result := a.dbClient(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", userIds).Order("id asc").Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersForUpdate", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	return me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: int64(len(usersDbMapper)),
+	}, nil
+}
+
+// userFilterQuery returns the query of the users that match the given filter without its paging and sorting.
+func (a DbAdapter) userFilterQuery(ctx context.Context, userFilter me.UserFilter) *gorm.DB {
 	var filter map_repo.User
-	qry := a.DbClient
+	qry := a.dbClient(ctx)
+	if userFilter.IncludeDeleted || userFilter.OnlyDeleted {
+		qry = qry.Unscoped()
+	}
+	if userFilter.OnlyDeleted {
+		qry = qry.Where("deleted_at IS NOT NULL")
+	}
 	if userFilter.Id.String() != "" && userFilter.Id != (uuid.UUID{}) {
 		filter.ID = userFilter.Id
 	}
+	if len(userFilter.Ids) > 0 {
+		qry = qry.Where("id IN ?", userFilter.Ids)
+	}
 	if userFilter.UserName != "" {
 		filter.UserName = userFilter.UserName
 	}
@@ -75,6 +273,18 @@ func (a DbAdapter) GetUsersByFilter(ctx context.Context, userFilter me.UserFilte
 	if !userFilter.UpdatedAtFrom.IsZero() && !userFilter.UpdatedAtTo.IsZero() {
 		qry = qry.Where("updated_at between ? and ?", userFilter.UpdatedAtFrom, userFilter.UpdatedAtTo)
 	}
+	if !userFilter.SuspendedUntilBefore.IsZero() {
+		qry = qry.Where("suspended_until <= ?", userFilter.SuspendedUntilBefore)
+	}
+	if !userFilter.ExpiresAtBefore.IsZero() {
+		qry = qry.Where("expires_at <= ?", userFilter.ExpiresAtBefore)
+	}
+	if !userFilter.LastLoginBefore.IsZero() {
+		qry = qry.Where("(last_login_at < ? OR last_login_at IS NULL)", userFilter.LastLoginBefore)
+	}
+	if !userFilter.LastLoginAfter.IsZero() {
+		qry = qry.Where("last_login_at > ?", userFilter.LastLoginAfter)
+	}
 	if userFilter.SearchText != "" {
 		qry = qry.Where(
 			qry.Where("UPPER(user_name) LIKE UPPER(?)", "%"+userFilter.SearchText+"%").
@@ -82,47 +292,35 @@ func (a DbAdapter) GetUsersByFilter(ctx context.Context, userFilter me.UserFilte
 				Or("UPPER(array_to_string(tags, ',')) LIKE UPPER(?)", "%"+userFilter.SearchText+"%"),
 		)
 	}
-	qry = qry.Where(filter)
-	var totalRows int64
-	result := qry.Model(&map_repo.User{}).Where(filter).Count(&totalRows)
-	if result.Error != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, result.Error.Error()))
-		totalRows = 0
-	}
-	if userFilter.Limit != 0 {
-		qry = qry.Limit(userFilter.Limit)
-	}
-	if userFilter.Offset != 0 {
-		qry = qry.Offset(userFilter.Offset)
+	return qry.Where(filter)
+}
+
+// userFilterSort returns the sort field of the given filter and whether it is descending, the field is none if the filter has no sort.
+func userFilterSort(userFilter me.UserFilter) (mo.UserSortField, bool) {
+	if _, ok := map_repo.UserSortMap[userFilter.SortField]; !ok || userFilter.SortType == "" {
+		return mo.UserSortFieldNONE, false
 	}
-	if userFilter.SortType != "" && userFilter.SortField != 0 {
-		sortStr := map_repo.UserSortMap[userFilter.SortField]
-		if userFilter.SortType == "desc" || userFilter.SortType == "DESC" {
-			sortStr += " desc"
-		} else {
-			sortStr += " asc"
-		}
-		qry = qry.Order(sortStr)
+	return userFilter.SortField, userFilter.SortType == "desc" || userFilter.SortType == "DESC"
+}
+
+// userFilterOrder returns the order clause of the given sort, the users with the same sort value are ordered by their ids.
+func userFilterOrder(sortField mo.UserSortField, sortDesc bool) string {
+	column, ok := map_repo.UserSortMap[sortField]
+	if !ok {
+		return "id asc"
 	}
-	result = qry.Find(&usersDbMapper)
-	if result.Error != nil {
-		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
-		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, result.Error.Error()))
-		return me.Users{}, result.Error
+	if sortDesc {
+		return column + " desc, id desc"
 	}
-	return me.Users{
-		Users:     usersDbMapper.ToEntities(),
-		TotalRows: totalRows,
-	}, nil
+	return column + " asc, id asc"
 }
 
 // SaveUser insert a new user or update the existing one in the database.
 func (a DbAdapter) SaveUser(ctx context.Context, user me.User) (me.User, error) {
 	userDbMapper := map_repo.NewUserFromEntity(user)
-	qry := a.DbClient
+	qry := a.dbClient(ctx).Omit(append(map_repo.UserErasureColumns, map_repo.UserActivityColumns...)...)
 	if user.Id.String() != "" && user.Id != (uuid.UUID{}) {
-		qry = qry.Omit("created_at")
+		qry = qry.Omit(append(append([]string{"created_at"}, map_repo.UserErasureColumns...), map_repo.UserActivityColumns...)...)
 	}
 	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 	if userDbMapper.ID != (uuid.UUID{}) {
@@ -139,12 +337,16 @@ func (a DbAdapter) SaveUser(ctx context.Context, user me.User) (me.User, error)
 	return *userDbMapper.ToEntity(), nil
 }
 
-// DeleteUser soft-deletes the given user in the database.
+// DeleteUser soft-deletes the given user in the database, the deleter is kept with the deletion time.
 func (a DbAdapter) DeleteUser(ctx context.Context, user me.User) (me.User, error) {
 	userDbMapper := map_repo.NewUserFromEntity(user)
 	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 	userDbMapper.DeletedBy, _ = uuid.Parse(userId)
-	result := a.DbClient.Delete(&userDbMapper)
+	userDbMapper.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	result := a.dbClient(ctx).Model(&map_repo.User{}).Where("id = ?", userDbMapper.ID).UpdateColumns(map[string]interface{}{
+		"deleted_at": userDbMapper.DeletedAt,
+		"deleted_by": userDbMapper.DeletedBy,
+	})
 	if result.Error != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUser", userId, result.Error.Error()))
@@ -153,13 +355,101 @@ func (a DbAdapter) DeleteUser(ctx context.Context, user me.User) (me.User, error
 	return *userDbMapper.ToEntity(), nil
 }
 
+// RestoreUser clears the soft-deletion of the given user in the database and returns the restored user.
+func (a DbAdapter) RestoreUser(ctx context.Context, user me.User) (me.User, error) {
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	updatedBy, _ := uuid.Parse(userId)
+	result := a.dbClient(ctx).Unscoped().Model(&map_repo.User{}).Where("id = ? AND deleted_at IS NOT NULL", user.Id).UpdateColumns(map[string]interface{}{
+		"deleted_at": nil,
+		"deleted_by": uuid.UUID{},
+		"updated_at": time.Now(),
+		"updated_by": updatedBy,
+	})
+	if result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return me.User{}, mo.ErrorUserIsNotDeleted
+	}
+	var userDbMapper map_repo.User
+	result = a.dbClient(ctx).Where("id = ?", user.Id).First(&userDbMapper)
+	if result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "RestoreUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	return *userDbMapper.ToEntity(), nil
+}
+
+// EraseUser irreversibly anonymizes the given user in the database and returns the anonymized tombstone.
+// The passwords, the login history and the outbox events of the user are hard-deleted, the reasons of the status transitions and the diffs of the audit entries are blanked.
+// The audit entries keep their diff hashes, so the audit trail is still verifiable.
+func (a DbAdapter) EraseUser(ctx context.Context, user me.User) (me.User, error) {
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	erasedBy, _ := uuid.Parse(userId)
+	now := time.Now()
+	userDbMapper := map_repo.NewUserFromEntity(user)
+	result := a.dbClient(ctx).Unscoped().Model(&map_repo.User{}).Where("id = ? AND erased_at IS NULL", user.Id).UpdateColumns(map[string]interface{}{
+		"user_name":          userDbMapper.UserName,
+		"email":              userDbMapper.Email,
+		"user_status":        userDbMapper.UserStatus,
+		"tags":               userDbMapper.Tags,
+		"first_name":         userDbMapper.FirstName,
+		"last_name":          userDbMapper.LastName,
+		"suspended_until":    nil,
+		"expires_at":         nil,
+		"last_login_at":      nil,
+		"last_activity_at":   nil,
+		"dormancy_warned_at": nil,
+		"erased_at":          now,
+		"updated_at":         now,
+		"updated_by":         erasedBy,
+		"deleted_by":         gorm.Expr("CASE WHEN deleted_at IS NULL THEN ? ELSE deleted_by END", erasedBy),
+		"deleted_at":         gorm.Expr("COALESCE(deleted_at, ?)", now),
+	})
+	if result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return me.User{}, mo.ErrorUserIsErased
+	}
+	if result = a.dbClient(ctx).Unscoped().Where("user_id = ?", user.Id).Delete(&map_repo.UserPassword{}); result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result = a.dbClient(ctx).Unscoped().Where("user_id = ?", user.Id).Delete(&map_repo.UserLoginHistory{}); result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result = a.dbClient(ctx).Unscoped().Where("user_id = ?", user.Id).Delete(&map_repo.OutboxEvent{}); result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result = a.dbClient(ctx).Unscoped().Model(&map_repo.UserStatusHistory{}).Where("user_id = ?", user.Id).UpdateColumn("reason", ""); result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	if result = a.dbClient(ctx).Unscoped().Model(&map_repo.AuditEntry{}).Where("target_id = ?", user.Id).UpdateColumn("diff", ""); result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	var erasedUserDbMapper map_repo.User
+	result = a.dbClient(ctx).Unscoped().Where("id = ?", user.Id).First(&erasedUserDbMapper)
+	if result.Error != nil {
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "EraseUser", userId, result.Error.Error()))
+		return me.User{}, result.Error
+	}
+	return *erasedUserDbMapper.ToEntity(), nil
+}
+
 // GetUserPasswordByUserId returns active password of the given user.
 func (a DbAdapter) GetUserPasswordByUserId(ctx context.Context, userId uuid.UUID) (me.UserPassword, error) {
 	var userPasswordsDbMapper *map_repo.UserPasswords
 	var filter map_repo.UserPassword
 	filter.UserID = userId
 	filter.PasswordStatus = int(mo.PasswordStatusACTIVE)
-	result := a.DbClient.Where(filter).Find(&userPasswordsDbMapper)
+	result := a.dbClient(ctx).Where(filter).Find(&userPasswordsDbMapper)
 	if result.Error != nil {
 		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
 		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUsersByFilter", userId, result.Error.Error()))
@@ -176,7 +466,7 @@ func (a DbAdapter) GetUserPasswordByUserId(ctx context.Context, userId uuid.UUID
 // ChangePassword changes the given user password in the database.
 func (a DbAdapter) ChangePassword(ctx context.Context, userPassword me.UserPassword) (me.UserPassword, error) {
 	userPasswordDbMapper := map_repo.NewUserPasswordFromEntity(userPassword)
-	qry := a.DbClient
+	qry := a.dbClient(ctx)
 	if userPassword.Id.String() != "" && userPassword.Id != (uuid.UUID{}) {
 		qry = qry.Omit("created_at")
 	}
@@ -194,3 +484,483 @@ func (a DbAdapter) ChangePassword(ctx context.Context, userPassword me.UserPassw
 	}
 	return *userPasswordDbMapper.ToEntity(), nil
 }
+
+// SaveUserStatusHistory inserts the given user status transition into the database.
+func (a DbAdapter) SaveUserStatusHistory(ctx context.Context, userStatusHistory me.UserStatusHistory) (me.UserStatusHistory, error) {
+	userStatusHistoryDbMapper := map_repo.NewUserStatusHistoryFromEntity(userStatusHistory)
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	userStatusHistoryDbMapper.CreatedBy, _ = uuid.Parse(userId)
+	result := a.dbClient(ctx).Create(&userStatusHistoryDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserStatusHistory", userId, result.Error.Error()))
+		return me.UserStatusHistory{}, result.Error
+	}
+	return *userStatusHistoryDbMapper.ToEntity(), nil
+}
+
+// GetUserStatusHistoryByFilter returns the status transitions of the user that match the given filter, latest first.
+func (a DbAdapter) GetUserStatusHistoryByFilter(ctx context.Context, userStatusHistoryFilter me.UserStatusHistoryFilter) (me.UserStatusHistories, error) {
+	var userStatusHistoriesDbMapper map_repo.UserStatusHistories
+	var filter map_repo.UserStatusHistory
+	filter.UserID = userStatusHistoryFilter.UserId
+	qry := a.dbClient(ctx).Where(filter)
+	var totalRows int64
+	result := qry.Model(&map_repo.UserStatusHistory{}).Count(&totalRows)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserStatusHistoryByFilter", userId, result.Error.Error()))
+		totalRows = 0
+	}
+	if userStatusHistoryFilter.Limit != 0 {
+		qry = qry.Limit(userStatusHistoryFilter.Limit)
+	}
+	if userStatusHistoryFilter.Offset != 0 {
+		qry = qry.Offset(userStatusHistoryFilter.Offset)
+	}
+	result = qry.Order("created_at desc").Find(&userStatusHistoriesDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserStatusHistoryByFilter", userId, result.Error.Error()))
+		return me.UserStatusHistories{}, result.Error
+	}
+	return me.UserStatusHistories{
+		UserStatusHistories: userStatusHistoriesDbMapper.ToEntities(),
+		TotalRows:           totalRows,
+	}, nil
+}
+
+// SaveUserActivities writes the given login and activity times of the users to the database.
+// The times never move backwards and any activity clears the dormancy warning of the user.
+func (a DbAdapter) SaveUserActivities(ctx context.Context, userActivities []me.UserActivity) error {
+	err := a.dbClient(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, userActivity := range userActivities {
+			columns := map[string]interface{}{
+				"last_activity_at":   gorm.Expr("GREATEST(COALESCE(last_activity_at, ?), ?)", userActivity.OccurredAt, userActivity.OccurredAt),
+				"dormancy_warned_at": nil,
+			}
+			if userActivity.UserActivityType == mo.UserActivityTypeLOGIN {
+				columns["last_login_at"] = gorm.Expr("GREATEST(COALESCE(last_login_at, ?), ?)", userActivity.OccurredAt, userActivity.OccurredAt)
+			}
+			result := tx.Model(&map_repo.User{}).Where("id = ?", userActivity.UserId).UpdateColumns(columns)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserActivities", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// GetSuspensionEndedUsers returns at most limit of the suspended users whose suspension is over at the given time, ordered by their ids after afterId.
+func (a DbAdapter) GetSuspensionEndedUsers(ctx context.Context, now time.Time, afterId uuid.UUID, limit int) (me.Users, error) {
+	var usersDbMapper map_repo.Users
+	qry := a.dbClient(ctx).Where("user_status = ?", int(mo.UserStatusSUSPENDED)).Where("suspended_until <= ?", now)
+	if afterId != (uuid.UUID{}) {
+		qry = qry.Where("id > ?", afterId)
+	}
+	result := qry.Order("id asc").Limit(limit).Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetSuspensionEndedUsers", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	return me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: int64(len(usersDbMapper)),
+	}, nil
+}
+
+// GetExpiredUsers returns at most limit of the users of the given statuses whose account is expired at the given time, ordered by their ids after afterId.
+func (a DbAdapter) GetExpiredUsers(ctx context.Context, userStatuses []mo.UserStatus, now time.Time, afterId uuid.UUID, limit int) (me.Users, error) {
+	var usersDbMapper map_repo.Users
+	statuses := make([]int, len(userStatuses))
+	for i, userStatus := range userStatuses {
+		statuses[i] = int(userStatus)
+	}
+	qry := a.dbClient(ctx).Where("user_status IN ?", statuses).Where("expires_at <= ?", now)
+	if afterId != (uuid.UUID{}) {
+		qry = qry.Where("id > ?", afterId)
+	}
+	result := qry.Order("id asc").Limit(limit).Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetExpiredUsers", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	return me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: int64(len(usersDbMapper)),
+	}, nil
+}
+
+// GetDormantUsers returns at most limit of the active users that have no activity since inactiveBefore, ordered by their ids after afterId.
+// If warnedBefore is zero only the users that are not warned yet are returned, otherwise only the users warned before it.
+func (a DbAdapter) GetDormantUsers(ctx context.Context, inactiveBefore time.Time, warnedBefore time.Time, afterId uuid.UUID, limit int) (me.Users, error) {
+	var usersDbMapper map_repo.Users
+	qry := a.dbClient(ctx).Where("user_status = ?", int(mo.UserStatusACTIVE)).
+		Where("COALESCE(last_activity_at, created_at) < ?", inactiveBefore)
+	if warnedBefore.IsZero() {
+		qry = qry.Where("dormancy_warned_at IS NULL")
+	} else {
+		qry = qry.Where("dormancy_warned_at <= ?", warnedBefore)
+	}
+	if afterId != (uuid.UUID{}) {
+		qry = qry.Where("id > ?", afterId)
+	}
+	result := qry.Order("id asc").Limit(limit).Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDormantUsers", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	return me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: int64(len(usersDbMapper)),
+	}, nil
+}
+
+// SaveUserDormancyWarning writes the dormancy warning time of the given user to the database.
+func (a DbAdapter) SaveUserDormancyWarning(ctx context.Context, userId uuid.UUID, warnedAt time.Time) error {
+	result := a.dbClient(ctx).Model(&map_repo.User{}).Where("id = ?", userId).UpdateColumn("dormancy_warned_at", warnedAt)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserDormancyWarning", userId, result.Error.Error()))
+		return result.Error
+	}
+	return nil
+}
+
+// SaveUserLoginHistory inserts the given authentication attempt into the database.
+func (a DbAdapter) SaveUserLoginHistory(ctx context.Context, userLoginHistory me.UserLoginHistory) (me.UserLoginHistory, error) {
+	userLoginHistoryDbMapper := map_repo.NewUserLoginHistoryFromEntity(userLoginHistory)
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	userLoginHistoryDbMapper.CreatedBy, _ = uuid.Parse(userId)
+	result := a.dbClient(ctx).Create(&userLoginHistoryDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserLoginHistory", userId, result.Error.Error()))
+		return me.UserLoginHistory{}, result.Error
+	}
+	return *userLoginHistoryDbMapper.ToEntity(), nil
+}
+
+// GetUserLoginHistoryByFilter returns the authentication attempts of the user that match the given filter, latest first.
+func (a DbAdapter) GetUserLoginHistoryByFilter(ctx context.Context, userLoginHistoryFilter me.UserLoginHistoryFilter) (me.UserLoginHistories, error) {
+	var userLoginHistoriesDbMapper map_repo.UserLoginHistories
+	var filter map_repo.UserLoginHistory
+	filter.UserID = userLoginHistoryFilter.UserId
+	qry := a.dbClient(ctx).Where(filter)
+	if !userLoginHistoryFilter.CreatedAtFrom.IsZero() && !userLoginHistoryFilter.CreatedAtTo.IsZero() {
+		qry = qry.Where("created_at between ? and ?", userLoginHistoryFilter.CreatedAtFrom, userLoginHistoryFilter.CreatedAtTo)
+	}
+	var totalRows int64
+	result := qry.Model(&map_repo.UserLoginHistory{}).Count(&totalRows)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserLoginHistoryByFilter", userId, result.Error.Error()))
+		totalRows = 0
+	}
+	if userLoginHistoryFilter.Limit != 0 {
+		qry = qry.Limit(userLoginHistoryFilter.Limit)
+	}
+	if userLoginHistoryFilter.Offset != 0 {
+		qry = qry.Offset(userLoginHistoryFilter.Offset)
+	}
+	result = qry.Order("created_at desc").Find(&userLoginHistoriesDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserLoginHistoryByFilter", userId, result.Error.Error()))
+		return me.UserLoginHistories{}, result.Error
+	}
+	return me.UserLoginHistories{
+		UserLoginHistories: userLoginHistoriesDbMapper.ToEntities(),
+		TotalRows:          totalRows,
+	}, nil
+}
+
+// SaveOutboxEvent inserts the given event into the outbox.
+func (a DbAdapter) SaveOutboxEvent(ctx context.Context, outboxEvent me.OutboxEvent) error {
+	outboxEventDbMapper, err := map_repo.NewOutboxEventFromEntity(outboxEvent)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveOutboxEvent", userId, err.Error()))
+		return err
+	}
+	userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+	outboxEventDbMapper.CreatedBy, _ = uuid.Parse(userId)
+	result := a.dbClient(ctx).Create(&outboxEventDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveOutboxEvent", userId, result.Error.Error()))
+		return result.Error
+	}
+	return nil
+}
+
+// GetPendingOutboxEvents returns the undelivered events of the outbox that are due at the given time, oldest first.
+// Only the oldest undelivered event of each user is returned, so the later events of a user wait until the earlier ones are delivered.
+func (a DbAdapter) GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]me.OutboxEvent, error) {
+	var outboxEventsDbMapper map_repo.OutboxEvents
+	qry := a.dbClient(ctx).Where("sent_at IS NULL").Where("next_attempt_at <= ?", now).
+		Where("NOT EXISTS (SELECT 1 FROM outbox_events AS earlier WHERE earlier.user_id = outbox_events.user_id" +
+			" AND earlier.sent_at IS NULL AND earlier.deleted_at IS NULL" +
+			" AND (earlier.created_at, earlier.id) < (outbox_events.created_at, outbox_events.id))").
+		Order("created_at asc, id asc")
+	if limit != 0 {
+		qry = qry.Limit(limit)
+	}
+	result := qry.Find(&outboxEventsDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetPendingOutboxEvents", userId, result.Error.Error()))
+		return nil, result.Error
+	}
+	outboxEvents, err := outboxEventsDbMapper.ToEntities()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetPendingOutboxEvents", userId, err.Error()))
+		return nil, err
+	}
+	return outboxEvents, nil
+}
+
+// SaveOutboxEventDelivery writes the result of the delivery attempt of the given event to the outbox.
+func (a DbAdapter) SaveOutboxEventDelivery(ctx context.Context, outboxEvent me.OutboxEvent) error {
+	columns := map[string]interface{}{
+		"attempts":        outboxEvent.Attempts,
+		"next_attempt_at": outboxEvent.NextAttemptAt,
+		"last_error":      outboxEvent.LastError,
+		"sent_at":         nil,
+	}
+	if !outboxEvent.SentAt.IsZero() {
+		columns["sent_at"] = outboxEvent.SentAt
+	}
+	result := a.dbClient(ctx).Model(&map_repo.OutboxEvent{}).Where("id = ?", outboxEvent.Id).UpdateColumns(columns)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveOutboxEventDelivery", userId, result.Error.Error()))
+		return result.Error
+	}
+	return nil
+}
+
+// SaveAuditEntry appends the given entry to the audit trail, chaining it to the last entry.
+// The audit trail is locked until the end of the transaction, so the entries are chained in the commit order.
+func (a DbAdapter) SaveAuditEntry(ctx context.Context, auditEntry me.AuditEntry) (me.AuditEntry, error) {
+	err := a.RunInTransaction(ctx, func(ctx context.Context) error {
+		result := a.dbClient(ctx).Exec("SELECT pg_advisory_xact_lock(?)", auditLockKey)
+		if result.Error != nil {
+			return result.Error
+		}
+		var lastAuditEntriesDbMapper map_repo.AuditEntries
+		result = a.dbClient(ctx).Unscoped().Order("seq desc").Limit(1).Find(&lastAuditEntriesDbMapper)
+		if result.Error != nil {
+			return result.Error
+		}
+		var previous *me.AuditEntry
+		if len(lastAuditEntriesDbMapper) > 0 {
+			previous = lastAuditEntriesDbMapper[0].ToEntity()
+		}
+		// The database keeps microseconds, the hashed time must survive the round trip.
+		auditEntry.Chain(previous, time.Now().UTC().Truncate(time.Microsecond))
+		auditEntryDbMapper := map_repo.NewAuditEntryFromEntity(auditEntry)
+		result = a.dbClient(ctx).Create(&auditEntryDbMapper)
+		if result.Error != nil {
+			return result.Error
+		}
+		auditEntry = *auditEntryDbMapper.ToEntity()
+		return nil
+	})
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveAuditEntry", userId, err.Error()))
+		return me.AuditEntry{}, err
+	}
+	return auditEntry, nil
+}
+
+// GetAuditTrailByFilter returns the audit trail entries that match the given filter, latest first.
+func (a DbAdapter) GetAuditTrailByFilter(ctx context.Context, auditFilter me.AuditFilter) (me.AuditEntries, error) {
+	var auditEntriesDbMapper map_repo.AuditEntries
+	var filter map_repo.AuditEntry
+	filter.CreatedBy = auditFilter.ActorId
+	filter.TargetID = auditFilter.TargetId
+	filter.AuditAction = int(auditFilter.AuditAction)
+	qry := a.dbClient(ctx).Where(filter)
+	if !auditFilter.CreatedAtFrom.IsZero() && !auditFilter.CreatedAtTo.IsZero() {
+		qry = qry.Where("created_at between ? and ?", auditFilter.CreatedAtFrom, auditFilter.CreatedAtTo)
+	}
+	var totalRows int64
+	result := qry.Model(&map_repo.AuditEntry{}).Count(&totalRows)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetAuditTrailByFilter", userId, result.Error.Error()))
+		totalRows = 0
+	}
+	if auditFilter.Limit != 0 {
+		qry = qry.Limit(auditFilter.Limit)
+	}
+	if auditFilter.Offset != 0 {
+		qry = qry.Offset(auditFilter.Offset)
+	}
+	result = qry.Order("seq desc").Find(&auditEntriesDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetAuditTrailByFilter", userId, result.Error.Error()))
+		return me.AuditEntries{}, result.Error
+	}
+	return me.AuditEntries{
+		AuditEntries: auditEntriesDbMapper.ToEntities(),
+		TotalRows:    totalRows,
+	}, nil
+}
+
+// GetAuditEntriesAfter returns at most limit of the audit trail entries after the given position, in the order of the audit trail.
+func (a DbAdapter) GetAuditEntriesAfter(ctx context.Context, afterSeq int64, limit int) ([]me.AuditEntry, error) {
+	var auditEntriesDbMapper map_repo.AuditEntries
+	result := a.dbClient(ctx).Unscoped().Where("seq > ?", afterSeq).Order("seq asc").Limit(limit).Find(&auditEntriesDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetAuditEntriesAfter", userId, result.Error.Error()))
+		return nil, result.Error
+	}
+	return auditEntriesDbMapper.ToEntities(), nil
+}
+
+// SaveUserReadAccesses inserts the given reads of the personal data of the users into the database.
+// The reads that are already written are skipped, so a batch whose flush is retried is not written twice.
+func (a DbAdapter) SaveUserReadAccesses(ctx context.Context, userReadAccesses []me.UserReadAccess) error {
+	userReadAccessesDbMapper := map_repo.NewUserReadAccessFromEntities(userReadAccesses)
+	result := a.dbClient(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&userReadAccessesDbMapper, 100)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserReadAccesses", userId, result.Error.Error()))
+		return result.Error
+	}
+	return nil
+}
+
+// GetUserReadAccessesByFilter returns the reads of the personal data of the user that match the given filter, latest first.
+func (a DbAdapter) GetUserReadAccessesByFilter(ctx context.Context, userReadAccessFilter me.UserReadAccessFilter) (me.UserReadAccesses, error) {
+	var userReadAccessesDbMapper map_repo.UserReadAccesses
+	var filter map_repo.UserReadAccess
+	filter.CreatedBy = userReadAccessFilter.ActorId
+	qry := a.dbClient(ctx).Where(filter).Where("user_ids @> ?", pq.StringArray{userReadAccessFilter.UserId.String()})
+	if !userReadAccessFilter.ReadAtFrom.IsZero() && !userReadAccessFilter.ReadAtTo.IsZero() {
+		qry = qry.Where("read_at between ? and ?", userReadAccessFilter.ReadAtFrom, userReadAccessFilter.ReadAtTo)
+	}
+	var totalRows int64
+	result := qry.Model(&map_repo.UserReadAccess{}).Count(&totalRows)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccessesByFilter", userId, result.Error.Error()))
+		totalRows = 0
+	}
+	if userReadAccessFilter.Limit != 0 {
+		qry = qry.Limit(userReadAccessFilter.Limit)
+	}
+	if userReadAccessFilter.Offset != 0 {
+		qry = qry.Offset(userReadAccessFilter.Offset)
+	}
+	result = qry.Order("read_at desc").Find(&userReadAccessesDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccessesByFilter", userId, result.Error.Error()))
+		return me.UserReadAccesses{}, result.Error
+	}
+	return me.UserReadAccesses{
+		UserReadAccesses: userReadAccessesDbMapper.ToEntities(),
+		TotalRows:        totalRows,
+	}, nil
+}
+
+// expiredRecordsQuery returns the query of the records of the given dataset that are older than the given time.
+// The superseded and the soft-deleted passwords are inactive as well as the passwords that are not active by their status.
+func (a DbAdapter) expiredRecordsQuery(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time) (*gorm.DB, error) {
+	qry := a.dbClient(ctx).Unscoped()
+	switch retentionDataset {
+	case mo.RetentionDatasetDELETEDUSER:
+		return qry.Model(&map_repo.User{}).Where("deleted_at < ? AND erased_at IS NULL", before), nil
+	case mo.RetentionDatasetINACTIVEPASSWORD:
+		return qry.Model(&map_repo.UserPassword{}).Where("updated_at < ?", before).
+			Where("deleted_at IS NOT NULL OR password_status <> ? OR EXISTS (SELECT 1 FROM user_passwords AS newer WHERE newer.user_id = user_passwords.user_id AND newer.created_at > user_passwords.created_at)", int(mo.PasswordStatusACTIVE)), nil
+	case mo.RetentionDatasetLOGINHISTORY:
+		return qry.Model(&map_repo.UserLoginHistory{}).Where("created_at < ?", before), nil
+	case mo.RetentionDatasetSENTOUTBOXEVENT:
+		return qry.Model(&map_repo.OutboxEvent{}).Where("sent_at < ?", before), nil
+	}
+	return nil, mo.ErrorRetentionDatasetIsNotValid
+}
+
+// CountExpiredRecords returns the number of the records of the given dataset that are older than the given time.
+func (a DbAdapter) CountExpiredRecords(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time) (int64, error) {
+	qry, err := a.expiredRecordsQuery(ctx, retentionDataset, before)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CountExpiredRecords", userId, err.Error()))
+		return 0, err
+	}
+	var count int64
+	result := qry.Count(&count)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "CountExpiredRecords", userId, result.Error.Error()))
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// DeleteExpiredRecords hard-deletes at most limit records of the given dataset that are older than the given time.
+// The users are not deleted by it, they are erased so that the audit trail still resolves them.
+func (a DbAdapter) DeleteExpiredRecords(ctx context.Context, retentionDataset mo.RetentionDataset, before time.Time, limit int) (int64, error) {
+	if retentionDataset == mo.RetentionDatasetDELETEDUSER {
+		err := mo.ErrorRetentionDatasetIsNotValid
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteExpiredRecords", userId, err.Error()))
+		return 0, err
+	}
+	qry, err := a.expiredRecordsQuery(ctx, retentionDataset, before)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteExpiredRecords", userId, err.Error()))
+		return 0, err
+	}
+	var ids []uuid.UUID
+	result := qry.Limit(limit).Pluck("id", &ids)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteExpiredRecords", userId, result.Error.Error()))
+		return 0, result.Error
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result = a.dbClient(ctx).Unscoped().Where("id IN ?", ids).Delete(qry.Statement.Model)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteExpiredRecords", userId, result.Error.Error()))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// GetDeletedUsersBefore returns at most limit soft-deleted users that are deleted before the given time and not erased yet.
+func (a DbAdapter) GetDeletedUsersBefore(ctx context.Context, before time.Time, limit int) (me.Users, error) {
+	var usersDbMapper map_repo.Users
+	result := a.dbClient(ctx).Unscoped().Where("deleted_at < ? AND erased_at IS NULL", before).Order("deleted_at").Limit(limit).Find(&usersDbMapper)
+	if result.Error != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetDeletedUsersBefore", userId, result.Error.Error()))
+		return me.Users{}, result.Error
+	}
+	return me.Users{
+		Users:     usersDbMapper.ToEntities(),
+		TotalRows: int64(len(usersDbMapper)),
+	}, nil
+}
@@ -2,16 +2,26 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	smodel "github.com/octoposprime/op-be-shared/pkg/model"
 	pb_logging "github.com/octoposprime/op-be-shared/pkg/proto/pb/logging"
 	tredis "github.com/octoposprime/op-be-shared/tool/redis"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
 	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 	map_repo "github.com/octoposprime/op-be-user/pkg/infrastructure/mapper/repository"
+	"github.com/redis/go-redis/v9"
 )
 
+// userActivityKeys are the hash keys that buffer the latest activity time of the users by the activity type.
+var userActivityKeys map[mo.UserActivityType]string = map[mo.UserActivityType]string{
+	mo.UserActivityTypeLOGIN:   "USERACTIVITY:LOGIN",
+	mo.UserActivityTypeREQUEST: "USERACTIVITY:REQUEST",
+}
+
 type RedisAdapter struct {
 	*tredis.RedisClient
 	Log func(ctx context.Context, logData *pb_logging.LogData) (*pb_logging.LoggingResult, error)
@@ -68,3 +78,255 @@ func (a RedisAdapter) ChangePassword(ctx context.Context, userPassword me.UserPa
 	}
 	return nil
 }
+
+// IncrementRateLimit increments the counter of the given key and returns its value in the current window.
+func (a RedisAdapter) IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	count, err := a.RedisClient.Incr(ctx, "RATELIMIT:"+key).Result()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "IncrementRateLimit", userId, err.Error()))
+		return 0, err
+	}
+	if count == 1 {
+		err = a.RedisClient.Expire(ctx, "RATELIMIT:"+key, window).Err()
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "IncrementRateLimit", userId, err.Error()))
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// SaveUserActivity buffers the latest activity time of the user in the redis.
+func (a RedisAdapter) SaveUserActivity(ctx context.Context, userActivity me.UserActivity) error {
+	err := a.RedisClient.HSet(ctx, userActivityKeys[userActivity.UserActivityType], userActivity.UserId.String(), userActivity.OccurredAt.UTC().Format(time.RFC3339Nano)).Err()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserActivity", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// GetUserActivitiesToFlush moves the buffered activities aside and returns them for writing to the database.
+// The activities that are moved aside by a failed flush are returned again before the new ones are moved.
+func (a RedisAdapter) GetUserActivitiesToFlush(ctx context.Context) ([]me.UserActivity, error) {
+	userActivities := []me.UserActivity{}
+	for userActivityType, key := range userActivityKeys {
+		flushKey := key + ":FLUSH"
+		flushExists, err := a.RedisClient.Exists(ctx, flushKey).Result()
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserActivitiesToFlush", userId, err.Error()))
+			return nil, err
+		}
+		if flushExists == 0 {
+			err = a.RedisClient.Rename(ctx, key, flushKey).Err()
+			if err != nil && err.Error() != "ERR no such key" {
+				userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+				go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserActivitiesToFlush", userId, err.Error()))
+				return nil, err
+			}
+		}
+		values, err := a.RedisClient.HGetAll(ctx, flushKey).Result()
+		if err != nil && err != redis.Nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserActivitiesToFlush", userId, err.Error()))
+			return nil, err
+		}
+		for userId, value := range values {
+			occurredAt, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				continue
+			}
+			userActivities = append(userActivities, *me.NewUserActivity(tuuid.FromString(userId), userActivityType, occurredAt))
+		}
+	}
+	return userActivities, nil
+}
+
+// DeleteFlushedUserActivities hard-deletes the activities that are returned by GetUserActivitiesToFlush.
+func (a RedisAdapter) DeleteFlushedUserActivities(ctx context.Context) error {
+	for _, key := range userActivityKeys {
+		err := a.RedisClient.Del(ctx, key+":FLUSH").Err()
+		if err != nil {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteFlushedUserActivities", userId, err.Error()))
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteUserActivitiesByUserId hard-deletes the buffered activities of the given user in the redis.
+// The activities that are moved aside for a flush are deleted as well.
+func (a RedisAdapter) DeleteUserActivitiesByUserId(ctx context.Context, userId uuid.UUID) error {
+	for _, key := range userActivityKeys {
+		for _, hashKey := range []string{key, key + ":FLUSH"} {
+			err := a.RedisClient.HDel(ctx, hashKey, userId.String()).Err()
+			if err != nil {
+				userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+				go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteUserActivitiesByUserId", userId, err.Error()))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ReserveIdempotencyKey marks the given key as in progress for the ttl if it is not used yet.
+// It returns the stored result and false if the key is already used.
+func (a RedisAdapter) ReserveIdempotencyKey(ctx context.Context, key string, requestHash string, ttl time.Duration) (me.IdempotentResult, bool, error) {
+	idempotentResult := *me.NewIdempotentResult(key, requestHash, true, me.User{}, "")
+	value, err := json.Marshal(idempotentResult)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReserveIdempotencyKey", userId, err.Error()))
+		return me.IdempotentResult{}, false, err
+	}
+	reserved, err := a.RedisClient.SetNX(ctx, "IDEMPOTENCY:"+key, value, ttl).Result()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReserveIdempotencyKey", userId, err.Error()))
+		return me.IdempotentResult{}, false, err
+	}
+	if reserved {
+		return idempotentResult, true, nil
+	}
+	stored, err := a.RedisClient.Get(ctx, "IDEMPOTENCY:"+key).Bytes()
+	if err == redis.Nil {
+		// The key has expired meanwhile, the caller sees it as still in progress and retries.
+		return idempotentResult, false, nil
+	}
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReserveIdempotencyKey", userId, err.Error()))
+		return me.IdempotentResult{}, false, err
+	}
+	if err := json.Unmarshal(stored, &idempotentResult); err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReserveIdempotencyKey", userId, err.Error()))
+		return me.IdempotentResult{}, false, err
+	}
+	return idempotentResult, false, nil
+}
+
+// SaveIdempotentResult stores the outcome of the request of the given key for the ttl.
+func (a RedisAdapter) SaveIdempotentResult(ctx context.Context, idempotentResult me.IdempotentResult, ttl time.Duration) error {
+	value, err := json.Marshal(idempotentResult)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveIdempotentResult", userId, err.Error()))
+		return err
+	}
+	err = a.RedisClient.Set(ctx, "IDEMPOTENCY:"+idempotentResult.Key, value, ttl).Err()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveIdempotentResult", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey hard-deletes the given key so that the request can be retried.
+func (a RedisAdapter) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	err := a.RedisClient.Del(ctx, "IDEMPOTENCY:"+key).Err()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteIdempotencyKey", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// userReadAccessKey is the list key that buffers the reads of the personal data of the users.
+const userReadAccessKey = "USERREADACCESS"
+
+// SaveUserReadAccess buffers the given read of the personal data of the users in the redis.
+func (a RedisAdapter) SaveUserReadAccess(ctx context.Context, userReadAccess me.UserReadAccess) error {
+	value, err := json.Marshal(userReadAccess)
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserReadAccess", userId, err.Error()))
+		return err
+	}
+	err = a.RedisClient.RPush(ctx, userReadAccessKey, value).Err()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "SaveUserReadAccess", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// GetUserReadAccessesToFlush moves the buffered reads aside and returns them for writing to the database.
+// The reads that are moved aside by a failed flush are returned again before the new ones are moved.
+func (a RedisAdapter) GetUserReadAccessesToFlush(ctx context.Context) ([]me.UserReadAccess, error) {
+	flushKey := userReadAccessKey + ":FLUSH"
+	flushExists, err := a.RedisClient.Exists(ctx, flushKey).Result()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccessesToFlush", userId, err.Error()))
+		return nil, err
+	}
+	if flushExists == 0 {
+		err = a.RedisClient.Rename(ctx, userReadAccessKey, flushKey).Err()
+		if err != nil && err.Error() != "ERR no such key" {
+			userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+			go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccessesToFlush", userId, err.Error()))
+			return nil, err
+		}
+	}
+	values, err := a.RedisClient.LRange(ctx, flushKey, 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "GetUserReadAccessesToFlush", userId, err.Error()))
+		return nil, err
+	}
+	userReadAccesses := []me.UserReadAccess{}
+	for _, value := range values {
+		var userReadAccess me.UserReadAccess
+		if err := json.Unmarshal([]byte(value), &userReadAccess); err != nil {
+			continue
+		}
+		userReadAccesses = append(userReadAccesses, userReadAccess)
+	}
+	return userReadAccesses, nil
+}
+
+// DeleteFlushedUserReadAccesses hard-deletes the reads that are returned by GetUserReadAccessesToFlush.
+func (a RedisAdapter) DeleteFlushedUserReadAccesses(ctx context.Context) error {
+	err := a.RedisClient.Del(ctx, userReadAccessKey+":FLUSH").Err()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "DeleteFlushedUserReadAccesses", userId, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// releaseLockScript deletes the lock only if it is still held by the owner, so that an expired and retaken lock is kept.
+const releaseLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
+
+// AcquireLock takes the given lock on behalf of the owner for the ttl, it returns false if the lock is held by another owner.
+func (a RedisAdapter) AcquireLock(ctx context.Context, key string, owner string, ttl time.Duration) (bool, error) {
+	locked, err := a.RedisClient.SetNX(ctx, "LOCK:"+key, owner, ttl).Result()
+	if err != nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "AcquireLock", userId, err.Error()))
+		return false, err
+	}
+	return locked, nil
+}
+
+// ReleaseLock frees the given lock if it is still held by the owner.
+func (a RedisAdapter) ReleaseLock(ctx context.Context, key string, owner string) error {
+	err := a.RedisClient.Eval(ctx, releaseLockScript, []string{"LOCK:" + key}, owner).Err()
+	if err != nil && err != redis.Nil {
+		userId, _ := ctx.Value(smodel.QueryKeyUid).(string)
+		go a.Log(context.Background(), me.NewLogData().GenerateLogData(pb_logging.LogType_LogTypeERROR, "ReleaseLock", userId, err.Error()))
+		return err
+	}
+	return nil
+}
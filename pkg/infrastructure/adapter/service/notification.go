@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/notification"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Notify sends the given notification to the notification micro service.
+func (a ServiceAdapter) Notify(ctx context.Context, notificationData *pb.NotificationData) (*pb.NotificationResult, error) {
+	conn, err := grpc.Dial(tconfig.GetServiceConfigInstance().Notification.Host+":"+tconfig.GetServiceConfigInstance().Notification.Port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Println(notificationData.String())
+		fmt.Println(err)
+		return &pb.NotificationResult{}, err
+	}
+	defer conn.Close()
+
+	pbResult, err := pb.NewNotificationSvcClient(conn).Notify(ctx, notificationData)
+	if err != nil {
+		fmt.Println(notificationData.String())
+		fmt.Println(err)
+		return &pb.NotificationResult{}, err
+	}
+	return pbResult, nil
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// DeadLetterStreamSuffix is appended to the channel name for the dead-letter stream of the channel.
+const DeadLetterStreamSuffix string = ":DLQ"
+
+// DeadLetter is a struct that represents the ebus mapper of a dead-lettered message.
+type DeadLetter struct {
+	channelName string
+	id          string
+	values      map[string]interface{}
+}
+
+// NewDeadLetter creates a new *DeadLetter from the id and the values of an entry of the dead-letter stream of the channel.
+func NewDeadLetter(channelName string, id string, values map[string]interface{}) *DeadLetter {
+	return &DeadLetter{
+		channelName: channelName,
+		id:          id,
+		values:      values,
+	}
+}
+
+// String returns a string representation of the DeadLetter.
+func (s *DeadLetter) String() string {
+	return fmt.Sprintf("ChannelName: %v, "+
+		"Id: %v, "+
+		"Values: %v",
+		s.channelName,
+		s.id,
+		s.values)
+}
+
+// NewDeadLetterValues returns the fields of the dead-letter stream entry of the given entity.
+func NewDeadLetterValues(entity me.DeadLetter) map[string]interface{} {
+	return map[string]interface{}{
+		"message_id":       entity.MessageId,
+		"message":          entity.Message,
+		"error":            entity.Error,
+		"attempts":         entity.Attempts,
+		"dead_lettered_at": entity.DeadLetteredAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// ToEntity returns a entity representation of the DeadLetter.
+func (s *DeadLetter) ToEntity() *me.DeadLetter {
+	messageId, _ := s.values["message_id"].(string)
+	message, _ := s.values["message"].(string)
+	errorText, _ := s.values["error"].(string)
+	attemptsStr, _ := s.values["attempts"].(string)
+	attempts, _ := strconv.ParseInt(attemptsStr, 10, 64)
+	deadLetteredAtStr, _ := s.values["dead_lettered_at"].(string)
+	deadLetteredAt, _ := time.Parse(time.RFC3339Nano, deadLetteredAtStr)
+	return &me.DeadLetter{
+		Id:             s.id,
+		ChannelName:    s.channelName,
+		MessageId:      messageId,
+		Message:        message,
+		Error:          errorText,
+		Attempts:       attempts,
+		ReceivedAt:     StreamIdTime(messageId),
+		DeadLetteredAt: deadLetteredAt,
+	}
+}
+
+// StreamIdTime returns the time part of the given stream entry id.
+func StreamIdTime(id string) time.Time {
+	ms, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CurrentSchemaVersion is the schema version of the payloads published by this service.
+const CurrentSchemaVersion int = 1
+
+// ErrorEnvelopeSchemaVersionIsNotSupported is returned for a message newer than the service can read.
+var ErrorEnvelopeSchemaVersionIsNotSupported error = errors.New("envelope schema version is not supported")
+
+// Envelope is a struct that represents the versioned wrapper of every event bus message.
+type Envelope struct {
+	Id            string          `json:"id"`             // Id is the unique id of the event, the consumers deduplicate by it.
+	Type          string          `json:"type"`           // Type is the type of the event.
+	SchemaVersion int             `json:"schema_version"` // SchemaVersion is the version of the payload shape.
+	Producer      string          `json:"producer"`       // Producer is the name of the service that published the event.
+	CorrelationId string          `json:"correlation_id"` // CorrelationId is the id of the request that caused the event.
+	ActorId       string          `json:"actor_id"`       // ActorId is the id of the user who caused the event.
+	OccurredAt    time.Time       `json:"occurred_at"`    // OccurredAt is the time of the event.
+	Payload       json.RawMessage `json:"payload"`        // Payload is the json representation of the event data.
+
+	// Only for the queries and their replies
+	ReplyTo   string     `json:"reply_to,omitempty"`   // ReplyTo is the stream that the reply of the query is sent to.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // ExpiresAt is the time after which the requester does not wait for the reply.
+	Error     string     `json:"error,omitempty"`      // Error is the reason of the failure of the query in a reply.
+}
+
+// NewEnvelope creates a new *Envelope of the current schema version.
+func NewEnvelope(id string,
+	eventType string,
+	producer string,
+	correlationId string,
+	actorId string,
+	occurredAt time.Time,
+	payload json.RawMessage) *Envelope {
+	return &Envelope{
+		Id:            id,
+		Type:          eventType,
+		SchemaVersion: CurrentSchemaVersion,
+		Producer:      producer,
+		CorrelationId: correlationId,
+		ActorId:       actorId,
+		OccurredAt:    occurredAt,
+		Payload:       payload,
+	}
+}
+
+// String returns a string representation of the Envelope.
+func (s *Envelope) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"Type: %v, "+
+		"SchemaVersion: %v, "+
+		"Producer: %v, "+
+		"CorrelationId: %v, "+
+		"ActorId: %v, "+
+		"OccurredAt: %v, "+
+		"Payload: %v, "+
+		"ReplyTo: %v, "+
+		"ExpiresAt: %v, "+
+		"Error: %v",
+		s.Id,
+		s.Type,
+		s.SchemaVersion,
+		s.Producer,
+		s.CorrelationId,
+		s.ActorId,
+		s.OccurredAt,
+		string(s.Payload),
+		s.ReplyTo,
+		s.ExpiresAt,
+		s.Error)
+}
+
+// upcasters converts an envelope of the key version to the next version.
+var upcasters map[int]func(envelope Envelope) (Envelope, error) = map[int]func(envelope Envelope) (Envelope, error){
+	// Version 0 is the bare payload that was published before the envelope, the payload shape is unchanged.
+	0: func(envelope Envelope) (Envelope, error) {
+		envelope.SchemaVersion = 1
+		return envelope, nil
+	},
+}
+
+// DecodeEnvelope parses the given message and upcasts it to the current schema version.
+// A message without an envelope is read as the version 0 payload of the given type.
+func DecodeEnvelope(message string, eventType string) (Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(message), &envelope); err != nil {
+		return Envelope{}, err
+	}
+	if envelope.SchemaVersion == 0 && envelope.Payload == nil {
+		envelope = Envelope{
+			Type:    eventType,
+			Payload: json.RawMessage(message),
+		}
+	}
+	for envelope.SchemaVersion < CurrentSchemaVersion {
+		upcaster, ok := upcasters[envelope.SchemaVersion]
+		if !ok {
+			return Envelope{}, ErrorEnvelopeSchemaVersionIsNotSupported
+		}
+		var err error
+		envelope, err = upcaster(envelope)
+		if err != nil {
+			return Envelope{}, err
+		}
+	}
+	if envelope.SchemaVersion > CurrentSchemaVersion {
+		return Envelope{}, ErrorEnvelopeSchemaVersionIsNotSupported
+	}
+	return envelope, nil
+}
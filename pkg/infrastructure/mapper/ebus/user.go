@@ -2,11 +2,13 @@ package infrastructure
 
 import (
 	"fmt"
+	"time"
 
 	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
 	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
 	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
 	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // User is a struct that represents the ebus mapper of a user basic values.
@@ -48,11 +50,15 @@ func NewUserFromEntity(entity me.User) *User {
 			Id:         entity.Id.String(),
 			Username:   entity.UserName,
 			Email:      entity.Email,
+			Role:       entity.Role,
 			UserType:   pb.UserType(entity.UserType),
 			UserStatus: pb.UserStatus(entity.UserStatus),
 			Tags:       entity.Tags,
 			FirstName:  entity.FirstName,
 			LastName:   entity.LastName,
+
+			SuspendedUntil: toTimestamp(entity.SuspendedUntil),
+			ExpiresAt:      toTimestamp(entity.ExpiresAt),
 		},
 	}
 }
@@ -69,11 +75,15 @@ func (s *User) ToEntity() *me.User {
 		User: mo.User{
 			UserName:   s.proto.Username,
 			Email:      s.proto.Email,
+			Role:       s.proto.Role,
 			UserType:   mo.UserType(s.proto.UserType),
 			UserStatus: mo.UserStatus(s.proto.UserStatus),
 			Tags:       s.proto.Tags,
 			FirstName:  s.proto.FirstName,
 			LastName:   s.proto.LastName,
+
+			SuspendedUntil: fromTimestamp(s.proto.SuspendedUntil),
+			ExpiresAt:      fromTimestamp(s.proto.ExpiresAt),
 		},
 	}
 }
@@ -99,3 +109,19 @@ func (s Users) ToPbs() *pb.Users {
 		Users: users,
 	}
 }
+
+// toTimestamp returns nil for the zero time so that it is not sent.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp returns the zero time for the unset timestamp.
+func fromTimestamp(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"encoding/json"
+
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tserialize "github.com/octoposprime/op-be-shared/tool/serialize"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// NewUserCommand returns the user command of the given envelope received from the channel.
+// The payload is a user status change on the status channel, a user password on the password reset channel and a user on the others.
+func NewUserCommand(channelName string, envelope Envelope) *me.UserCommand {
+	userCommand := me.NewUserCommand(envelope.Id, channelName, me.User{}, me.UserStatusChange{}, me.UserPassword{})
+	switch channelName {
+	case mo.ChannelChangeUserStatus:
+		userStatusChange := tserialize.SerializeFromJson[*pb.UserStatusChange](string(envelope.Payload))
+		if userStatusChange != nil {
+			userCommand.UserStatusChange = *me.NewUserStatusChange(
+				tuuid.FromString(userStatusChange.UserId),
+				mo.UserStatus(userStatusChange.UserStatus),
+				userStatusChange.Reason,
+				fromTimestamp(userStatusChange.SuspendedUntil),
+			)
+		}
+	case mo.ChannelResetUserPassword:
+		userPassword := tserialize.SerializeFromJson[*pb.UserPassword](string(envelope.Payload))
+		if userPassword != nil {
+			userCommand.UserPassword = me.UserPassword{
+				UserId: tuuid.FromString(userPassword.UserId),
+				UserPassword: mo.UserPassword{
+					Password: userPassword.Password,
+				},
+			}
+		}
+	case smodel.ChannelCreateUser, smodel.ChannelDeleteUser, mo.ChannelUpdateUser, mo.ChannelChangeUserRole:
+		user := tserialize.SerializeFromJson[*pb.User](string(envelope.Payload))
+		if user != nil {
+			userCommand.User = *NewUser(user).ToEntity()
+		}
+	}
+	return userCommand
+}
+
+// RedactedMessage replaces a message of a channel with secrets that can not be decoded to be redacted.
+const RedactedMessage string = "[REDACTED]"
+
+// RedactUserCommandMessage returns the given message of the channel without the password of a password reset, to be kept or shown after it is handled.
+// The messages of the other channels are returned as they are.
+func RedactUserCommandMessage(channelName string, message string) string {
+	if channelName != mo.ChannelResetUserPassword {
+		return message
+	}
+	envelope, err := DecodeEnvelope(message, channelName)
+	if err != nil {
+		return RedactedMessage
+	}
+	userPassword := tserialize.SerializeFromJson[*pb.UserPassword](string(envelope.Payload))
+	if userPassword == nil {
+		return RedactedMessage
+	}
+	userPassword.Password = ""
+	payload, err := json.Marshal(userPassword)
+	if err != nil {
+		return RedactedMessage
+	}
+	envelope.Payload = payload
+	redacted, err := json.Marshal(envelope)
+	if err != nil {
+		return RedactedMessage
+	}
+	return string(redacted)
+}
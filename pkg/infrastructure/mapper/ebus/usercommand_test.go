@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	smodel "github.com/octoposprime/op-be-shared/pkg/model"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+func TestRedactUserCommandMessage(t *testing.T) {
+	payload, err := json.Marshal(&pb.UserPassword{UserId: "user-1", Password: "secret-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := json.Marshal(NewEnvelope("event-1", mo.ChannelResetUserPassword, "test", "cid-1", "actor-1", time.Now(), payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		channelName string
+		message     string
+		want        func(redacted string) bool
+	}{
+		{"PasswordReset", mo.ChannelResetUserPassword, string(message), func(redacted string) bool {
+			envelope, err := DecodeEnvelope(redacted, mo.ChannelResetUserPassword)
+			return err == nil && envelope.Id == "event-1" && envelope.CorrelationId == "cid-1" &&
+				strings.Contains(string(envelope.Payload), "user-1") && !strings.Contains(redacted, "secret-password")
+		}},
+		{"PasswordResetWithoutEnvelope", mo.ChannelResetUserPassword, string(payload), func(redacted string) bool {
+			return strings.Contains(redacted, "user-1") && !strings.Contains(redacted, "secret-password")
+		}},
+		{"MalformedPasswordReset", mo.ChannelResetUserPassword, "secret-password", func(redacted string) bool {
+			return redacted == RedactedMessage
+		}},
+		{"OtherChannel", smodel.ChannelCreateUser, string(message), func(redacted string) bool {
+			return redacted == string(message)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactUserCommandMessage(tt.channelName, tt.message); !tt.want(got) {
+				t.Errorf("RedactUserCommandMessage() = %v", got)
+			}
+		})
+	}
+}
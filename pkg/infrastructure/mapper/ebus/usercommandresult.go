@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// ReplyStreamSuffix is appended to a channel name to get the stream of the results of its commands.
+const ReplyStreamSuffix string = ":REPLY"
+
+// UserCommandResult is a struct that represents the ebus mapper of the outcome of a user command.
+type UserCommandResult struct {
+	proto *pb.UserCommandResult
+}
+
+// NewUserCommandResult creates a new *UserCommandResult.
+func NewUserCommandResult(pb *pb.UserCommandResult) *UserCommandResult {
+	return &UserCommandResult{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserCommandResult.
+func (s *UserCommandResult) String() string {
+	return fmt.Sprintf("CommandId: %v, "+
+		"ChannelName: %v, "+
+		"UserId: %v, "+
+		"Success: %v, "+
+		"Error: %v, "+
+		"User: %v, "+
+		"OccurredAt: %v",
+		s.proto.CommandId,
+		s.proto.ChannelName,
+		s.proto.UserId,
+		s.proto.Success,
+		s.proto.Error,
+		s.proto.User,
+		s.proto.OccurredAt)
+}
+
+// NewUserCommandResultFromEntity creates a new *UserCommandResult from entity.
+func NewUserCommandResultFromEntity(entity me.UserCommandResult) *UserCommandResult {
+	return &UserCommandResult{
+		&pb.UserCommandResult{
+			CommandId:   entity.CommandId,
+			ChannelName: entity.ChannelName,
+			UserId:      entity.UserId.String(),
+			Success:     entity.Success,
+			Error:       entity.Error,
+			User:        NewUserFromEntity(entity.User).ToPb(),
+			OccurredAt:  toTimestamp(entity.OccurredAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserCommandResult.
+func (s *UserCommandResult) ToPb() *pb.UserCommandResult {
+	return s.proto
+}
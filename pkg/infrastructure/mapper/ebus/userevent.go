@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserEventChannelMap is the map of the event bus channels that the user events are published to.
+var UserEventChannelMap map[mo.UserEventType]string = map[mo.UserEventType]string{
+	mo.UserEventTypeCREATED:         "UserCreated",
+	mo.UserEventTypeUPDATED:         "UserUpdated",
+	mo.UserEventTypeSTATUSCHANGED:   "UserStatusChanged",
+	mo.UserEventTypeROLECHANGED:     "UserRoleChanged",
+	mo.UserEventTypePASSWORDCHANGED: "PasswordChanged",
+	mo.UserEventTypeDELETED:         "UserDeleted",
+	mo.UserEventTypeRESTORED:        "UserRestored",
+	mo.UserEventTypeERASED:          "UserErased",
+}
+
+// UserEvent is a struct that represents the ebus mapper of a user domain event.
+type UserEvent struct {
+	proto *pb.UserEvent
+}
+
+// NewUserEvent creates a new *UserEvent.
+func NewUserEvent(pb *pb.UserEvent) *UserEvent {
+	return &UserEvent{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserEvent.
+func (s *UserEvent) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserEventType: %v, "+
+		"Before: %v, "+
+		"After: %v, "+
+		"ActorId: %v, "+
+		"OccurredAt: %v",
+		s.proto.Id,
+		s.proto.UserEventType,
+		s.proto.Before,
+		s.proto.After,
+		s.proto.ActorId,
+		s.proto.OccurredAt)
+}
+
+// NewUserEventFromEntity creates a new *UserEvent from entity.
+func NewUserEventFromEntity(entity me.UserEvent) *UserEvent {
+	return &UserEvent{
+		&pb.UserEvent{
+			Id:            entity.Id.String(),
+			UserEventType: pb.UserEventType(entity.UserEventType),
+			Before:        NewUserFromEntity(entity.Before).ToPb(),
+			After:         NewUserFromEntity(entity.After).ToPb(),
+			ActorId:       entity.ActorId.String(),
+			OccurredAt:    toTimestamp(entity.OccurredAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserEvent.
+func (s *UserEvent) ToPb() *pb.UserEvent {
+	return s.proto
+}
+
+// ToEntity returns a entity representation of the UserEvent.
+func (s *UserEvent) ToEntity() *me.UserEvent {
+	return &me.UserEvent{
+		Id:            tuuid.FromString(s.proto.Id),
+		UserEventType: mo.UserEventType(s.proto.UserEventType),
+		Before:        *NewUser(s.proto.Before).ToEntity(),
+		After:         *NewUser(s.proto.After).ToEntity(),
+		ActorId:       tuuid.FromString(s.proto.ActorId),
+		OccurredAt:    fromTimestamp(s.proto.OccurredAt),
+	}
+}
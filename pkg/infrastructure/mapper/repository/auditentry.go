@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// AuditEntry is a struct that represents the db mapper of an audit trail entry.
+type AuditEntry struct {
+	tgorm.Model
+	Seq         int64     `json:"seq" gorm:"not null;uniqueIndex"`                     // Seq is the position of the entry in the audit trail.
+	AuditAction int       `json:"audit_action" gorm:"not null;default:0;index"`        // AuditAction is the action of the command.
+	TargetID    uuid.UUID `json:"target_id" gorm:"type:uuid;default:uuid_nil();index"` // TargetId is the id of the user the command is run on.
+	RequestID   string    `json:"request_id" gorm:"not null;default:''"`               // RequestId is the id of the request that ran the command.
+	Diff        string    `json:"diff" gorm:"type:text;not null;default:''"`           // Diff is the JSON of the changed fields, it is kept as text to keep its hash.
+	DiffHash    string    `json:"diff_hash" gorm:"not null;default:''"`                // DiffHash is the hash of the diff, it is kept when the diff is erased.
+	PrevHash    string    `json:"prev_hash" gorm:"not null;default:''"`                // PrevHash is the hash of the previous entry.
+	Hash        string    `json:"hash" gorm:"not null;default:''"`                     // Hash is the hash of the entry.
+}
+
+// NewAuditEntry creates a new *AuditEntry.
+func NewAuditEntry(id uuid.UUID,
+	seq int64,
+	auditAction int,
+	targetId uuid.UUID,
+	requestId string,
+	diff string,
+	diffHash string,
+	prevHash string,
+	hash string) *AuditEntry {
+	return &AuditEntry{
+		Model:       tgorm.Model{ID: id},
+		Seq:         seq,
+		AuditAction: auditAction,
+		TargetID:    targetId,
+		RequestID:   requestId,
+		Diff:        diff,
+		DiffHash:    diffHash,
+		PrevHash:    prevHash,
+		Hash:        hash,
+	}
+}
+
+// String returns a string representation of the AuditEntry.
+func (s *AuditEntry) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"Seq: %v, "+
+		"AuditAction: %v, "+
+		"TargetId: %v, "+
+		"RequestId: %v, "+
+		"Diff: %v, "+
+		"DiffHash: %v, "+
+		"PrevHash: %v, "+
+		"Hash: %v",
+		s.ID,
+		s.Seq,
+		s.AuditAction,
+		s.TargetID,
+		s.RequestID,
+		s.Diff,
+		s.DiffHash,
+		s.PrevHash,
+		s.Hash)
+}
+
+// NewAuditEntryFromEntity creates a new *AuditEntry from entity.
+func NewAuditEntryFromEntity(entity me.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		Model:       tgorm.Model{ID: entity.Id, CreatedAt: entity.CreatedAt, CreatedBy: entity.ActorId},
+		Seq:         entity.Seq,
+		AuditAction: int(entity.AuditAction),
+		TargetID:    entity.TargetId,
+		RequestID:   entity.RequestId,
+		Diff:        entity.Diff,
+		DiffHash:    entity.DiffHash,
+		PrevHash:    entity.PrevHash,
+		Hash:        entity.Hash,
+	}
+}
+
+// ToEntity returns a entity representation of the AuditEntry.
+func (s *AuditEntry) ToEntity() *me.AuditEntry {
+	return &me.AuditEntry{
+		Id:          s.ID,
+		Seq:         s.Seq,
+		ActorId:     s.CreatedBy,
+		AuditAction: mo.AuditAction(s.AuditAction),
+		TargetId:    s.TargetID,
+		RequestId:   s.RequestID,
+		Diff:        s.Diff,
+		DiffHash:    s.DiffHash,
+		PrevHash:    s.PrevHash,
+		Hash:        s.Hash,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+type AuditEntries []*AuditEntry
+
+// ToEntities creates a new []me.AuditEntry entity.
+func (s AuditEntries) ToEntities() []me.AuditEntry {
+	auditEntries := make([]me.AuditEntry, len(s))
+	for i, auditEntry := range s {
+		auditEntries[i] = *auditEntry.ToEntity()
+	}
+	return auditEntries
+}
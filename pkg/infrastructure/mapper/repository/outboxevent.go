@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// OutboxEvent is a struct that represents the db mapper of a user event waiting in the outbox for delivery.
+type OutboxEvent struct {
+	tgorm.Model
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;default:uuid_nil();index"` // UserId is the id of the user of the event.
+	UserEventType int        `json:"user_event_type" gorm:"not null;default:0"`         // UserEventType is the type of the event.
+	Payload       string     `json:"payload" gorm:"type:jsonb;not null"`                // Payload is the json representation of the event.
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`                // Attempts is the number of the failed delivery attempts.
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index"`             // NextAttemptAt is the earliest time of the next delivery attempt.
+	LastError     string     `json:"last_error" gorm:"not null;default:''"`             // LastError is the error of the last failed delivery attempt.
+	SentAt        *time.Time `json:"sent_at" gorm:"index"`                              // SentAt is the delivery time, it is NULL until the event is delivered.
+}
+
+// String returns a string representation of the OutboxEvent.
+func (s *OutboxEvent) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"UserEventType: %v, "+
+		"Attempts: %v, "+
+		"NextAttemptAt: %v, "+
+		"LastError: %v, "+
+		"SentAt: %v",
+		s.ID,
+		s.UserID,
+		s.UserEventType,
+		s.Attempts,
+		s.NextAttemptAt,
+		s.LastError,
+		s.SentAt)
+}
+
+// NewOutboxEventFromEntity creates a new *OutboxEvent from entity.
+func NewOutboxEventFromEntity(entity me.OutboxEvent) (*OutboxEvent, error) {
+	payload, err := json.Marshal(entity.UserEvent)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Model:         tgorm.Model{ID: entity.Id},
+		UserID:        entity.UserEvent.After.Id,
+		UserEventType: int(entity.UserEvent.UserEventType),
+		Payload:       string(payload),
+		Attempts:      entity.Attempts,
+		NextAttemptAt: entity.NextAttemptAt,
+		LastError:     entity.LastError,
+		SentAt:        toNullTime(entity.SentAt),
+	}, nil
+}
+
+// ToEntity returns a entity representation of the OutboxEvent.
+func (s *OutboxEvent) ToEntity() (*me.OutboxEvent, error) {
+	var userEvent me.UserEvent
+	if err := json.Unmarshal([]byte(s.Payload), &userEvent); err != nil {
+		return nil, err
+	}
+	return &me.OutboxEvent{
+		Id:            s.ID,
+		UserEvent:     userEvent,
+		Attempts:      s.Attempts,
+		NextAttemptAt: s.NextAttemptAt,
+		LastError:     s.LastError,
+		SentAt:        fromNullTime(s.SentAt),
+		CreatedAt:     s.CreatedAt,
+	}, nil
+}
+
+type OutboxEvents []*OutboxEvent
+
+// ToEntities creates a new []me.OutboxEvent entity.
+func (s OutboxEvents) ToEntities() ([]me.OutboxEvent, error) {
+	outboxEvents := make([]me.OutboxEvent, len(s))
+	for i, outboxEvent := range s {
+		entity, err := outboxEvent.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		outboxEvents[i] = *entity
+	}
+	return outboxEvents, nil
+}
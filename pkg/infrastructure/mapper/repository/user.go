@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -22,8 +23,25 @@ type User struct {
 	Tags       pq.StringArray `json:"tags" gorm:"type:text[]"`               // Tags is the tags of the user.
 	FirstName  string         `json:"first_name" gorm:"not null;default:''"` // FirstName is the first name of the user.
 	LastName   string         `json:"last_name" gorm:"not null;default:''"`  // FirstName is the last name of the user.
+
+	SuspendedUntil *time.Time `json:"suspended_until" gorm:"index"` // SuspendedUntil is the end of the suspension of the user.
+	ExpiresAt      *time.Time `json:"expires_at" gorm:"index"`      // ExpiresAt is the expiry time of the user account.
+
+	// Only written by the activity flush and the dormancy job
+	LastLoginAt      *time.Time `json:"last_login_at" gorm:"index"`      // LastLoginAt is the time of the last login.
+	LastActivityAt   *time.Time `json:"last_activity_at" gorm:"index"`   // LastActivityAt is the time of the last activity.
+	DormancyWarnedAt *time.Time `json:"dormancy_warned_at" gorm:"index"` // DormancyWarnedAt is the time the user was warned about the dormancy of the account.
+
+	// Only written by the erasure
+	ErasedAt *time.Time `json:"erased_at" gorm:"index"` // ErasedAt is the erasure time, the user is only an anonymized tombstone after it.
 }
 
+// UserActivityColumns are the columns of the User that are not written by SaveUser.
+var UserActivityColumns []string = []string{"last_login_at", "last_activity_at", "dormancy_warned_at"}
+
+// UserErasureColumns are the columns of the User that are only written by the erasure.
+var UserErasureColumns []string = []string{"erased_at"}
+
 // NewUser creates a new *User.
 func NewUser(id uuid.UUID,
 	userName string,
@@ -33,7 +51,9 @@ func NewUser(id uuid.UUID,
 	userStatus int,
 	tags pq.StringArray,
 	firstName string,
-	lastName string) *User {
+	lastName string,
+	suspendedUntil *time.Time,
+	expiresAt *time.Time) *User {
 	return &User{
 		Model:      tgorm.Model{ID: id},
 		UserName:   userName,
@@ -44,6 +64,9 @@ func NewUser(id uuid.UUID,
 		Tags:       tags,
 		FirstName:  firstName,
 		LastName:   lastName,
+
+		SuspendedUntil: suspendedUntil,
+		ExpiresAt:      expiresAt,
 	}
 }
 
@@ -57,7 +80,9 @@ func (s *User) String() string {
 		"UserStatus: %v, "+
 		"Tags: %v, "+
 		"FirstName: %v, "+
-		"LastName: %v",
+		"LastName: %v, "+
+		"SuspendedUntil: %v, "+
+		"ExpiresAt: %v",
 		s.ID,
 		s.UserName,
 		s.Email,
@@ -66,7 +91,9 @@ func (s *User) String() string {
 		s.UserStatus,
 		s.Tags,
 		s.FirstName,
-		s.LastName)
+		s.LastName,
+		s.SuspendedUntil,
+		s.ExpiresAt)
 }
 
 // NewUserFromEntity creates a new *User from entity.
@@ -81,6 +108,9 @@ func NewUserFromEntity(entity me.User) *User {
 		Tags:       entity.Tags,
 		FirstName:  entity.FirstName,
 		LastName:   entity.LastName,
+
+		SuspendedUntil: toNullTime(entity.SuspendedUntil),
+		ExpiresAt:      toNullTime(entity.ExpiresAt),
 	}
 }
 
@@ -97,9 +127,20 @@ func (s *User) ToEntity() *me.User {
 			Tags:       s.Tags,
 			FirstName:  s.FirstName,
 			LastName:   s.LastName,
+
+			SuspendedUntil: fromNullTime(s.SuspendedUntil),
+			ExpiresAt:      fromNullTime(s.ExpiresAt),
 		},
 		CreatedAt: s.CreatedAt,
 		UpdatedAt: s.UpdatedAt,
+
+		LastLoginAt:      fromNullTime(s.LastLoginAt),
+		LastActivityAt:   fromNullTime(s.LastActivityAt),
+		DormancyWarnedAt: fromNullTime(s.DormancyWarnedAt),
+
+		DeletedAt: s.DeletedAt.Time,
+		DeletedBy: s.DeletedBy,
+		ErasedAt:  fromNullTime(s.ErasedAt),
 	}
 }
 
@@ -122,3 +163,19 @@ func (s Users) ToEntities() []me.User {
 	}
 	return users
 }
+
+// toNullTime returns nil for the zero time so that it is stored as NULL.
+func toNullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// fromNullTime returns the zero time for NULL.
+func fromNullTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
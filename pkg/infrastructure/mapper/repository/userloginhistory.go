@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserLoginHistory is a struct that represents the db mapper of an authentication attempt of a user.
+type UserLoginHistory struct {
+	tgorm.Model
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;default:uuid_nil();index"` // UserId is the id of the user.
+	Success   bool      `json:"success" gorm:"not null;default:false"`             // Success is true if the authentication attempt succeeded.
+	Reason    string    `json:"reason" gorm:"not null;default:''"`                 // Reason is the failure reason of the authentication attempt.
+	IpAddress string    `json:"ip_address" gorm:"not null;default:''"`             // IpAddress is the ip address that the attempt comes from.
+	UserAgent string    `json:"user_agent" gorm:"not null;default:''"`             // UserAgent is the user agent of the attempt.
+	Client    string    `json:"client" gorm:"not null;default:''"`                 // Client is the client application of the attempt.
+}
+
+// NewUserLoginHistory creates a new *UserLoginHistory.
+func NewUserLoginHistory(id uuid.UUID,
+	userId uuid.UUID,
+	success bool,
+	reason string,
+	ipAddress string,
+	userAgent string,
+	client string) *UserLoginHistory {
+	return &UserLoginHistory{
+		Model:     tgorm.Model{ID: id},
+		UserID:    userId,
+		Success:   success,
+		Reason:    reason,
+		IpAddress: ipAddress,
+		UserAgent: userAgent,
+		Client:    client,
+	}
+}
+
+// String returns a string representation of the UserLoginHistory.
+func (s *UserLoginHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"Success: %v, "+
+		"Reason: %v, "+
+		"IpAddress: %v, "+
+		"UserAgent: %v, "+
+		"Client: %v",
+		s.ID,
+		s.UserID,
+		s.Success,
+		s.Reason,
+		s.IpAddress,
+		s.UserAgent,
+		s.Client)
+}
+
+// NewUserLoginHistoryFromEntity creates a new *UserLoginHistory from entity.
+func NewUserLoginHistoryFromEntity(entity me.UserLoginHistory) *UserLoginHistory {
+	return &UserLoginHistory{
+		Model:     tgorm.Model{ID: entity.Id},
+		UserID:    entity.UserId,
+		Success:   entity.Success,
+		Reason:    entity.Reason,
+		IpAddress: entity.IpAddress,
+		UserAgent: entity.UserAgent,
+		Client:    entity.Client,
+	}
+}
+
+// ToEntity returns a entity representation of the UserLoginHistory.
+func (s *UserLoginHistory) ToEntity() *me.UserLoginHistory {
+	return &me.UserLoginHistory{
+		Id:        s.ID,
+		UserId:    s.UserID,
+		Success:   s.Success,
+		Reason:    s.Reason,
+		IpAddress: s.IpAddress,
+		UserAgent: s.UserAgent,
+		Client:    s.Client,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type UserLoginHistories []*UserLoginHistory
+
+// ToEntities creates a new []me.UserLoginHistory entity.
+func (s UserLoginHistories) ToEntities() []me.UserLoginHistory {
+	userLoginHistories := make([]me.UserLoginHistory, len(s))
+	for i, userLoginHistory := range s {
+		userLoginHistories[i] = *userLoginHistory.ToEntity()
+	}
+	return userLoginHistories
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserPageToken is a struct that represents the position after the last user of a page for the keyset pagination.
+// It is sent to the clients as an opaque string, the sort of the next page must be the same as the sort of the page.
+type UserPageToken struct {
+	SortField mo.UserSortField `json:"f"` // SortField is the sort field of the page, it is none if the page is only ordered by id.
+	SortDesc  bool             `json:"d"` // SortDesc is true if the page is sorted descending.
+	SortValue *string          `json:"v"` // SortValue is the value of the sort column of the last user, it is nil for a null column.
+	Id        uuid.UUID        `json:"i"` // Id is the id of the last user.
+}
+
+// NewUserPageToken creates a new *UserPageToken after the given user.
+func NewUserPageToken(user User, sortField mo.UserSortField, sortDesc bool) *UserPageToken {
+	userPageToken := &UserPageToken{
+		SortField: sortField,
+		SortDesc:  sortDesc,
+		Id:        user.ID,
+	}
+	if sortValue, ok := UserSortValueMap[sortField]; ok {
+		userPageToken.SortValue = sortValue(user)
+	}
+	return userPageToken
+}
+
+// ParseUserPageToken returns the *UserPageToken of the given opaque string.
+func ParseUserPageToken(token string) (*UserPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, mo.ErrorUserPageTokenIsNotValid
+	}
+	var userPageToken UserPageToken
+	if err := json.Unmarshal(data, &userPageToken); err != nil {
+		return nil, mo.ErrorUserPageTokenIsNotValid
+	}
+	// A token is only made after a user, with a sort value for a sort column only.
+	_, sorted := UserSortMap[userPageToken.SortField]
+	if userPageToken.Id == (uuid.UUID{}) ||
+		(!sorted && (userPageToken.SortField != mo.UserSortFieldNONE || userPageToken.SortDesc || userPageToken.SortValue != nil)) {
+		return nil, mo.ErrorUserPageTokenIsNotValid
+	}
+	return &userPageToken, nil
+}
+
+// String returns the opaque string of the UserPageToken.
+func (s *UserPageToken) String() string {
+	data, _ := json.Marshal(s)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Where returns the condition of the users after the UserPageToken with its values.
+// The users are ordered by the sort column and then by id in the same direction, the nulls are last in the ascending order as postgres does.
+func (s *UserPageToken) Where() (string, []interface{}) {
+	column, ok := UserSortMap[s.SortField]
+	if !ok {
+		return "id > ?", []interface{}{s.Id}
+	}
+	op := ">"
+	if s.SortDesc {
+		op = "<"
+	}
+	switch {
+	case s.SortValue == nil && s.SortDesc:
+		return fmt.Sprintf("((%[1]s IS NULL AND id %[2]s ?) OR %[1]s IS NOT NULL)", column, op), []interface{}{s.Id}
+	case s.SortValue == nil:
+		return fmt.Sprintf("(%[1]s IS NULL AND id %[2]s ?)", column, op), []interface{}{s.Id}
+	case s.SortDesc:
+		return fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op), []interface{}{*s.SortValue, *s.SortValue, s.Id}
+	default:
+		return fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?) OR %[1]s IS NULL)", column, op), []interface{}{*s.SortValue, *s.SortValue, s.Id}
+	}
+}
@@ -0,0 +1,123 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+func TestNewUserPageToken(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	lastLoginAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	user := User{Model: tgorm.Model{ID: id}, UserName: "user"}
+	loggedInUser := user
+	loggedInUser.LastLoginAt = &lastLoginAt
+	tests := []struct {
+		name      string
+		user      User
+		sortField mo.UserSortField
+		sortDesc  bool
+		want      *string
+	}{
+		{"NoSort", user, mo.UserSortFieldNONE, false, nil},
+		{"SortValue", user, mo.UserSortFieldName, false, toSortValue("user")},
+		{"SortValueDesc", user, mo.UserSortFieldName, true, toSortValue("user")},
+		{"NullSortValue", user, mo.UserSortFieldLastLogin, false, nil},
+		{"TimeSortValue", loggedInUser, mo.UserSortFieldLastLogin, true, toSortValue(lastLoginAt.Format(time.RFC3339Nano))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUserPageToken(tt.user, tt.sortField, tt.sortDesc)
+			if got.Id != id || got.SortField != tt.sortField || got.SortDesc != tt.sortDesc || !reflect.DeepEqual(got.SortValue, tt.want) {
+				t.Errorf("NewUserPageToken() = %+v, want the id, the sort and the sort value %v", got, tt.want)
+			}
+			parsed, err := ParseUserPageToken(got.String())
+			if err != nil {
+				t.Fatalf("ParseUserPageToken() error = %v", err)
+			}
+			if !reflect.DeepEqual(parsed, got) {
+				t.Errorf("ParseUserPageToken() = %+v, want %+v", parsed, got)
+			}
+		})
+	}
+}
+
+func TestParseUserPageToken_NotValid(t *testing.T) {
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+	id := "00000000-0000-0000-0000-000000000001"
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"NotBase64", "not a token!"},
+		{"NotJson", encode("not json")},
+		{"TamperedBase64", encode(`{"f":0,"d":false,"v":null,"i":"` + id + `"}`)[1:]},
+		{"NoId", encode(`{"f":0,"d":false,"v":null}`)},
+		{"UnknownSortField", encode(`{"f":99,"d":false,"v":null,"i":"` + id + `"}`)},
+		{"SortValueWithoutSort", encode(`{"f":0,"d":false,"v":"user","i":"` + id + `"}`)},
+		{"DescWithoutSort", encode(`{"f":0,"d":true,"v":null,"i":"` + id + `"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseUserPageToken(tt.token); err != mo.ErrorUserPageTokenIsNotValid {
+				t.Errorf("ParseUserPageToken() error = %v, want %v", err, mo.ErrorUserPageTokenIsNotValid)
+			}
+		})
+	}
+}
+
+func TestUserPageToken_Where(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tests := []struct {
+		name       string
+		token      UserPageToken
+		wantWhere  string
+		wantValues []interface{}
+	}{
+		{
+			name:       "NoSort",
+			token:      UserPageToken{SortField: mo.UserSortFieldNONE, Id: id},
+			wantWhere:  "id > ?",
+			wantValues: []interface{}{id},
+		},
+		{
+			name:       "Asc",
+			token:      UserPageToken{SortField: mo.UserSortFieldName, SortValue: toSortValue("user"), Id: id},
+			wantWhere:  "(user_name > ? OR (user_name = ? AND id > ?) OR user_name IS NULL)",
+			wantValues: []interface{}{"user", "user", id},
+		},
+		{
+			name:       "Desc",
+			token:      UserPageToken{SortField: mo.UserSortFieldName, SortDesc: true, SortValue: toSortValue("user"), Id: id},
+			wantWhere:  "(user_name < ? OR (user_name = ? AND id < ?))",
+			wantValues: []interface{}{"user", "user", id},
+		},
+		{
+			name:       "AscAfterNull",
+			token:      UserPageToken{SortField: mo.UserSortFieldLastLogin, Id: id},
+			wantWhere:  "(last_login_at IS NULL AND id > ?)",
+			wantValues: []interface{}{id},
+		},
+		{
+			name:       "DescAfterNull",
+			token:      UserPageToken{SortField: mo.UserSortFieldLastLogin, SortDesc: true, Id: id},
+			wantWhere:  "((last_login_at IS NULL AND id < ?) OR last_login_at IS NOT NULL)",
+			wantValues: []interface{}{id},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, values := tt.token.Where()
+			if where != tt.wantWhere || !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("Where() = %v, %v, want %v, %v", where, values, tt.wantWhere, tt.wantValues)
+			}
+		})
+	}
+}
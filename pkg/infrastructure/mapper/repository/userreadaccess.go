@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserReadAccess is a struct that represents the db mapper of a read of the personal data of users.
+type UserReadAccess struct {
+	tgorm.Model
+	Operation string         `json:"operation" gorm:"not null;default:''"`        // Operation is the name of the query that returned the data.
+	Filter    string         `json:"filter" gorm:"type:text;not null;default:''"` // Filter is the JSON of the filter used by the query.
+	UserIDs   pq.StringArray `json:"user_ids" gorm:"type:uuid[];index:,type:gin"` // UserIds are the ids of the returned users.
+	RequestID string         `json:"request_id" gorm:"not null;default:''"`       // RequestId is the id of the request that ran the query.
+	ReadAt    time.Time      `json:"read_at" gorm:"not null;index"`               // ReadAt is the time of the read.
+}
+
+// NewUserReadAccess creates a new *UserReadAccess.
+func NewUserReadAccess(id uuid.UUID,
+	operation string,
+	filter string,
+	userIds pq.StringArray,
+	requestId string,
+	readAt time.Time) *UserReadAccess {
+	return &UserReadAccess{
+		Model:     tgorm.Model{ID: id},
+		Operation: operation,
+		Filter:    filter,
+		UserIDs:   userIds,
+		RequestID: requestId,
+		ReadAt:    readAt,
+	}
+}
+
+// String returns a string representation of the UserReadAccess.
+func (s *UserReadAccess) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"Operation: %v, "+
+		"Filter: %v, "+
+		"UserIds: %v, "+
+		"RequestId: %v, "+
+		"ReadAt: %v",
+		s.ID,
+		s.Operation,
+		s.Filter,
+		s.UserIDs,
+		s.RequestID,
+		s.ReadAt)
+}
+
+// NewUserReadAccessFromEntity creates a new *UserReadAccess from entity.
+func NewUserReadAccessFromEntity(entity me.UserReadAccess) *UserReadAccess {
+	userIds := make(pq.StringArray, len(entity.UserIds))
+	for i, userId := range entity.UserIds {
+		userIds[i] = userId.String()
+	}
+	return &UserReadAccess{
+		Model:     tgorm.Model{ID: entity.Id, CreatedBy: entity.ActorId},
+		Operation: entity.Operation,
+		Filter:    entity.Filter,
+		UserIDs:   userIds,
+		RequestID: entity.RequestId,
+		ReadAt:    entity.ReadAt,
+	}
+}
+
+// ToEntity returns a entity representation of the UserReadAccess.
+func (s *UserReadAccess) ToEntity() *me.UserReadAccess {
+	userIds := make([]uuid.UUID, len(s.UserIDs))
+	for i, userId := range s.UserIDs {
+		userIds[i] = tuuid.FromString(userId)
+	}
+	return &me.UserReadAccess{
+		Id:        s.ID,
+		ActorId:   s.CreatedBy,
+		Operation: s.Operation,
+		Filter:    s.Filter,
+		UserIds:   userIds,
+		RequestId: s.RequestID,
+		ReadAt:    s.ReadAt,
+	}
+}
+
+type UserReadAccesses []*UserReadAccess
+
+// NewUserReadAccessFromEntities creates a new []*UserReadAccess from entities.
+func NewUserReadAccessFromEntities(entities []me.UserReadAccess) UserReadAccesses {
+	userReadAccesses := make([]*UserReadAccess, len(entities))
+	for i, entity := range entities {
+		userReadAccesses[i] = NewUserReadAccessFromEntity(entity)
+	}
+	return userReadAccesses
+}
+
+// ToEntities creates a new []me.UserReadAccess entity.
+func (s UserReadAccesses) ToEntities() []me.UserReadAccess {
+	userReadAccesses := make([]me.UserReadAccess, len(s))
+	for i, userReadAccess := range s {
+		userReadAccesses[i] = *userReadAccess.ToEntity()
+	}
+	return userReadAccesses
+}
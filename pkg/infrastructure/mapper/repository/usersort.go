@@ -1,12 +1,34 @@
 package infrastructure
 
 import (
+	"time"
+
 	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
 )
 
 var UserSortMap map[mo.UserSortField]string = map[mo.UserSortField]string{
 	mo.UserSortFieldId:        "id",
-	mo.UserSortFieldName:      "name",
+	mo.UserSortFieldName:      "user_name",
 	mo.UserSortFieldCreatedAt: "created_at",
 	mo.UserSortFieldUpdatedAt: "updated_at",
+	mo.UserSortFieldLastLogin: "last_login_at",
+}
+
+// UserSortValueMap returns the value of the sort column of the user for the page tokens, it is nil for a null column.
+var UserSortValueMap map[mo.UserSortField]func(user User) *string = map[mo.UserSortField]func(user User) *string{
+	mo.UserSortFieldId:        func(user User) *string { return toSortValue(user.ID.String()) },
+	mo.UserSortFieldName:      func(user User) *string { return toSortValue(user.UserName) },
+	mo.UserSortFieldCreatedAt: func(user User) *string { return toSortValue(user.CreatedAt.Format(time.RFC3339Nano)) },
+	mo.UserSortFieldUpdatedAt: func(user User) *string { return toSortValue(user.UpdatedAt.Format(time.RFC3339Nano)) },
+	mo.UserSortFieldLastLogin: func(user User) *string {
+		if user.LastLoginAt == nil {
+			return nil
+		}
+		return toSortValue(user.LastLoginAt.Format(time.RFC3339Nano))
+	},
+}
+
+// toSortValue returns the pointer of the given sort value.
+func toSortValue(value string) *string {
+	return &value
 }
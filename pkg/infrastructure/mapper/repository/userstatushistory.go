@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	tgorm "github.com/octoposprime/op-be-shared/tool/gorm"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserStatusHistory is a struct that represents the db mapper of a user status transition.
+type UserStatusHistory struct {
+	tgorm.Model
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;default:uuid_nil();index"` // UserId is the id of the user.
+	FromStatus int       `json:"from_status" gorm:"not null;default:0"`             // FromStatus is the status of the user before the transition.
+	ToStatus   int       `json:"to_status" gorm:"not null;default:0"`               // ToStatus is the status of the user after the transition.
+	Reason     string    `json:"reason" gorm:"not null;default:''"`                 // Reason is the reason of the transition.
+}
+
+// NewUserStatusHistory creates a new *UserStatusHistory.
+func NewUserStatusHistory(id uuid.UUID,
+	userId uuid.UUID,
+	fromStatus int,
+	toStatus int,
+	reason string) *UserStatusHistory {
+	return &UserStatusHistory{
+		Model:      tgorm.Model{ID: id},
+		UserID:     userId,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Reason:     reason,
+	}
+}
+
+// String returns a string representation of the UserStatusHistory.
+func (s *UserStatusHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"FromStatus: %v, "+
+		"ToStatus: %v, "+
+		"Reason: %v",
+		s.ID,
+		s.UserID,
+		s.FromStatus,
+		s.ToStatus,
+		s.Reason)
+}
+
+// NewUserStatusHistoryFromEntity creates a new *UserStatusHistory from entity.
+func NewUserStatusHistoryFromEntity(entity me.UserStatusHistory) *UserStatusHistory {
+	return &UserStatusHistory{
+		Model:      tgorm.Model{ID: entity.Id, CreatedBy: entity.ActorId},
+		UserID:     entity.UserId,
+		FromStatus: int(entity.FromStatus),
+		ToStatus:   int(entity.ToStatus),
+		Reason:     entity.Reason,
+	}
+}
+
+// ToEntity returns a entity representation of the UserStatusHistory.
+func (s *UserStatusHistory) ToEntity() *me.UserStatusHistory {
+	return &me.UserStatusHistory{
+		Id:         s.ID,
+		UserId:     s.UserID,
+		FromStatus: mo.UserStatus(s.FromStatus),
+		ToStatus:   mo.UserStatus(s.ToStatus),
+		Reason:     s.Reason,
+		ActorId:    s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+type UserStatusHistories []*UserStatusHistory
+
+// ToEntities creates a new []me.UserStatusHistory entity.
+func (s UserStatusHistories) ToEntities() []me.UserStatusHistory {
+	userStatusHistories := make([]me.UserStatusHistory, len(s))
+	for i, userStatusHistory := range s {
+		userStatusHistories[i] = *userStatusHistory.ToEntity()
+	}
+	return userStatusHistories
+}
@@ -0,0 +1,25 @@
+package presentation
+
+import (
+	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	"google.golang.org/grpc"
+)
+
+// The file formats of the import and the export.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Cli is the command line client of the gRPC API
+type Cli struct {
+	userClient pb_user.UserSvcClient
+}
+
+// NewCli creates a new instance of Cli
+func NewCli(conn grpc.ClientConnInterface) *Cli {
+	api := &Cli{
+		userClient: pb_user.NewUserSvcClient(conn),
+	}
+	return api
+}
@@ -0,0 +1,28 @@
+package presentation
+
+import (
+	"context"
+	"io"
+
+	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+)
+
+// ExportUsers writes the users export of the given request to the given writer while the chunks are received from the service.
+func (a *Cli) ExportUsers(ctx context.Context, writer io.Writer, request *pb_user.UserExportRequest) error {
+	stream, err := a.userClient.ExportUsers(ctx, request)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
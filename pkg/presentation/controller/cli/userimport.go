@@ -0,0 +1,184 @@
+package presentation
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+)
+
+// userImportRecord is a row of the imported file, the CSV header and the NDJSON keys are the json tags.
+type userImportRecord struct {
+	UserName   string   `json:"username"`
+	Email      string   `json:"email"`
+	Role       string   `json:"role"`
+	UserType   int32    `json:"user_type"`
+	UserStatus int32    `json:"user_status"`
+	Tags       []string `json:"tags"`
+	FirstName  string   `json:"first_name"`
+	LastName   string   `json:"last_name"`
+	Password   string   `json:"password"`
+	Invite     bool     `json:"invite"`
+}
+
+// toPb returns a protobuf representation of the userImportRecord.
+func (s userImportRecord) toPb(line int) *pb_user.UserImportRow {
+	return &pb_user.UserImportRow{
+		Line: int32(line),
+		User: &pb_user.User{
+			Username:   s.UserName,
+			Email:      s.Email,
+			Role:       s.Role,
+			UserType:   pb_user.UserType(s.UserType),
+			UserStatus: pb_user.UserStatus(s.UserStatus),
+			Tags:       s.Tags,
+			FirstName:  s.FirstName,
+			LastName:   s.LastName,
+		},
+		Password: s.Password,
+		Invite:   s.Invite,
+	}
+}
+
+// ImportUsers streams the users of the given CSV or NDJSON file to the service and returns the outcome of each row.
+func (a *Cli) ImportUsers(ctx context.Context, reader io.Reader, format string, mode pb_user.UserImportMode, dryRun bool) (*pb_user.UserImportResults, error) {
+	var rows []*pb_user.UserImportRow
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readUserImportCsv(reader)
+	case FormatNDJSON:
+		rows, err = readUserImportNdjson(reader)
+	default:
+		err = fmt.Errorf("unknown import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the import file has no rows")
+	}
+	stream, err := a.userClient.ImportUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := stream.Send(&pb_user.UserImportRequest{Mode: mode, DryRun: dryRun, Row: row}); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// WriteUserImportResults writes the outcome of each row and the totals of the given import.
+func WriteUserImportResults(writer io.Writer, results *pb_user.UserImportResults) {
+	for _, result := range results.UserImportResults {
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(writer, "line %v: error: %v\n", result.Line, result.Error)
+		case result.UserId != "":
+			fmt.Fprintf(writer, "line %v: imported: %v, invited: %v\n", result.Line, result.UserId, result.Invited)
+		default:
+			fmt.Fprintf(writer, "line %v: valid\n", result.Line)
+		}
+	}
+	fmt.Fprintf(writer, "imported: %v, failed: %v, dry run: %v\n", results.Imported, results.Failed, results.DryRun)
+}
+
+// readUserImportCsv reads the rows of a CSV file with a header, the tags are separated by semicolons.
+func readUserImportCsv(reader io.Reader) ([]*pb_user.UserImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	value := func(fields []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	var rows []*pb_user.UserImportRow
+	for {
+		fields, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := csvReader.FieldPos(0)
+		record := userImportRecord{
+			UserName:  value(fields, "username"),
+			Email:     value(fields, "email"),
+			Role:      value(fields, "role"),
+			FirstName: value(fields, "first_name"),
+			LastName:  value(fields, "last_name"),
+			Password:  value(fields, "password"),
+		}
+		if record.UserType, err = parseInt32(value(fields, "user_type")); err != nil {
+			return nil, fmt.Errorf("line %v: user_type: %w", line, err)
+		}
+		if record.UserStatus, err = parseInt32(value(fields, "user_status")); err != nil {
+			return nil, fmt.Errorf("line %v: user_status: %w", line, err)
+		}
+		if invite := value(fields, "invite"); invite != "" {
+			if record.Invite, err = strconv.ParseBool(invite); err != nil {
+				return nil, fmt.Errorf("line %v: invite: %w", line, err)
+			}
+		}
+		for _, tag := range strings.Split(value(fields, "tags"), ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				record.Tags = append(record.Tags, tag)
+			}
+		}
+		rows = append(rows, record.toPb(line))
+	}
+	return rows, nil
+}
+
+// readUserImportNdjson reads the rows of a file with a JSON object on each line, the empty lines are skipped.
+func readUserImportNdjson(reader io.Reader) ([]*pb_user.UserImportRow, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var rows []*pb_user.UserImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record userImportRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		rows = append(rows, record.toPb(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// parseInt32 returns the given number, an empty value is zero.
+func parseInt32(value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 32)
+	return int32(number), err
+}
@@ -1,14 +1,21 @@
 package presentation
 
 import (
+	"context"
 	"net"
+	"strings"
 
+	"github.com/google/uuid"
 	pb_error "github.com/octoposprime/op-be-shared/pkg/proto/pb/error"
 	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
 	tgrpc "github.com/octoposprime/op-be-shared/tool/grpc"
 	pp_command "github.com/octoposprime/op-be-user/internal/application/presentation/port/command"
 	pp_query "github.com/octoposprime/op-be-user/internal/application/presentation/port/query"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	tconfig "github.com/octoposprime/op-be-user/tool/config"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 // Grpc is the gRPC API for the application
@@ -35,7 +42,8 @@ func (a *Grpc) Serve(port string) {
 		panic(err)
 	}
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(tgrpc.Interceptor),
+		grpc.ChainUnaryInterceptor(tgrpc.Interceptor, correlationInterceptor, idempotencyInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
 	)
 	pb_error.RegisterErorrSvcServer(s, a)
 	pb_user.RegisterUserSvcServer(s, a)
@@ -43,3 +51,104 @@ func (a *Grpc) Serve(port string) {
 		panic(err)
 	}
 }
+
+// correlationInterceptor puts the correlation id of the request into the context, a new one is generated if the caller sends none.
+func correlationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	correlationId := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-correlation-id"); len(values) > 0 {
+			correlationId = values[0]
+		} else if values := md.Get("x-request-id"); len(values) > 0 {
+			correlationId = values[0]
+		}
+	}
+	if correlationId == "" {
+		correlationId = uuid.New().String()
+	}
+	return handler(context.WithValue(ctx, mo.QueryKeyCorrelationId, correlationId), req)
+}
+
+// contextServerStream is a grpc.ServerStream with the context prepared by the interceptors.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context prepared by the interceptors.
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// streamInterceptor runs the unary interceptors that prepare the context for the streaming requests.
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	unaryInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}
+	_, err := tgrpc.Interceptor(ss.Context(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return correlationInterceptor(ctx, req, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		})
+	})
+	return err
+}
+
+// idempotencyInterceptor puts the idempotency key sent by the caller into the context.
+func idempotencyInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("idempotency-key"); len(values) > 0 && values[0] != "" {
+			ctx = context.WithValue(ctx, mo.QueryKeyIdempotencyKey, values[0])
+		} else if values := md.Get("x-idempotency-key"); len(values) > 0 && values[0] != "" {
+			ctx = context.WithValue(ctx, mo.QueryKeyIdempotencyKey, values[0])
+		}
+	}
+	return handler(ctx, req)
+}
+
+// clientIp returns the ip address of the caller.
+// The address forwarded by the gateway is only honoured if the request comes from a trusted proxy, the forwarded addresses
+// are read from the right and the first one that is not a trusted proxy is the caller, since the left ones can be forged.
+func clientIp(ctx context.Context) string {
+	trustedProxies := tconfig.GetServiceConfigInstance().Registration.TrustedProxies
+	peerIp := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		peerIp = host
+	}
+	if peerIp == "" || !isTrustedProxy(peerIp, trustedProxies) {
+		return peerIp
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if forwardedFor := md.Get("x-forwarded-for"); len(forwardedFor) > 0 {
+			forwardedIps := strings.Split(strings.Join(forwardedFor, ","), ",")
+			for i := len(forwardedIps) - 1; i >= 0; i-- {
+				forwardedIp := strings.TrimSpace(forwardedIps[i])
+				if forwardedIp != "" && !isTrustedProxy(forwardedIp, trustedProxies) {
+					return forwardedIp
+				}
+			}
+		}
+		if realIp := md.Get("x-real-ip"); len(realIp) > 0 && realIp[0] != "" {
+			return strings.TrimSpace(realIp[0])
+		}
+	}
+	return peerIp
+}
+
+// isTrustedProxy returns true if the given ip is one of the given trusted proxy ips or is in one of their cidrs.
+func isTrustedProxy(ip string, trustedProxies []string) bool {
+	parsedIp := net.ParseIP(ip)
+	if parsedIp == nil {
+		return false
+	}
+	for _, trustedProxy := range trustedProxies {
+		if _, network, err := net.ParseCIDR(trustedProxy); err == nil {
+			if network.Contains(parsedIp) {
+				return true
+			}
+		} else if trustedIp := net.ParseIP(trustedProxy); trustedIp != nil && trustedIp.Equal(parsedIp) {
+			return true
+		}
+	}
+	return false
+}
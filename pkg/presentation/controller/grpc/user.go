@@ -1,9 +1,12 @@
 package presentation
 
 import (
+	"bufio"
 	"context"
+	"io"
 
 	pb_user "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
 	dto "github.com/octoposprime/op-be-user/pkg/presentation/dto"
 )
 
@@ -43,8 +46,201 @@ func (a *Grpc) DeleteUser(ctx context.Context, user *pb_user.User) (*pb_user.Use
 	return dto.NewUserFromEntity(data).ToPb(), err
 }
 
+// RestoreUser sends the given soft-deleted user to the application layer for undoing the deletion.
+func (a *Grpc) RestoreUser(ctx context.Context, user *pb_user.User) (*pb_user.User, error) {
+	data, err := a.commandHandler.RestoreUser(ctx, *dto.NewUser(user).ToEntity())
+	return dto.NewUserFromEntity(data).ToPb(), err
+}
+
+// EraseUser sends the given user to the application layer for irreversibly anonymizing its personal data.
+func (a *Grpc) EraseUser(ctx context.Context, user *pb_user.User) (*pb_user.User, error) {
+	data, err := a.commandHandler.EraseUser(ctx, *dto.NewUser(user).ToEntity())
+	return dto.NewUserFromEntity(data).ToPb(), err
+}
+
+// BatchUpdateUserStatus moves the users of the given batch to the given status and returns the outcome of each user.
+func (a *Grpc) BatchUpdateUserStatus(ctx context.Context, request *pb_user.UserBatchRequest) (*pb_user.UserBatchResults, error) {
+	data, err := a.commandHandler.BatchUpdateUserStatus(ctx, *dto.NewUserBatch(request).ToEntity())
+	return dto.NewUserBatchResultFromEntities(data).ToPbs(), err
+}
+
+// BatchUpdateUserRole changes the role of the users of the given batch and returns the outcome of each user.
+func (a *Grpc) BatchUpdateUserRole(ctx context.Context, request *pb_user.UserBatchRequest) (*pb_user.UserBatchResults, error) {
+	data, err := a.commandHandler.BatchUpdateUserRole(ctx, *dto.NewUserBatch(request).ToEntity())
+	return dto.NewUserBatchResultFromEntities(data).ToPbs(), err
+}
+
+// BatchAddTags adds the given tags to the users of the given batch and returns the outcome of each user.
+func (a *Grpc) BatchAddTags(ctx context.Context, request *pb_user.UserBatchRequest) (*pb_user.UserBatchResults, error) {
+	data, err := a.commandHandler.BatchAddTags(ctx, *dto.NewUserBatch(request).ToEntity())
+	return dto.NewUserBatchResultFromEntities(data).ToPbs(), err
+}
+
+// BatchRemoveTags removes the given tags from the users of the given batch and returns the outcome of each user.
+func (a *Grpc) BatchRemoveTags(ctx context.Context, request *pb_user.UserBatchRequest) (*pb_user.UserBatchResults, error) {
+	data, err := a.commandHandler.BatchRemoveTags(ctx, *dto.NewUserBatch(request).ToEntity())
+	return dto.NewUserBatchResultFromEntities(data).ToPbs(), err
+}
+
+// BatchDeleteUsers soft-deletes the users of the given batch and returns the outcome of each user.
+func (a *Grpc) BatchDeleteUsers(ctx context.Context, request *pb_user.UserBatchRequest) (*pb_user.UserBatchResults, error) {
+	data, err := a.commandHandler.BatchDeleteUsers(ctx, *dto.NewUserBatch(request).ToEntity())
+	return dto.NewUserBatchResultFromEntities(data).ToPbs(), err
+}
+
 // ChangePassword sends the given user password to the application layer for changing user password.
 func (a *Grpc) ChangePassword(ctx context.Context, userPassword *pb_user.UserPassword) (*pb_user.UserPasswordResult, error) {
 	err := a.commandHandler.ChangePassword(ctx, *dto.NewUserPassword(userPassword).ToEntity())
 	return &pb_user.UserPasswordResult{}, err
 }
+
+// Register sends the given public self-registration request to the application layer for creating a new pending user.
+func (a *Grpc) Register(ctx context.Context, registration *pb_user.UserRegistration) (*pb_user.User, error) {
+	data, err := a.commandHandler.RegisterUser(ctx, *dto.NewUserRegistration(registration).ToEntity(clientIp(ctx)))
+	return dto.NewUserFromEntity(data).ToPb(), err
+}
+
+// ImportUsers receives the rows of a bulk import and sends them to the application layer for creating the users.
+// The rows are received in chunks while the application layer asks for them, so the memory use does not grow with the number of the rows.
+func (a *Grpc) ImportUsers(stream pb_user.UserSvc_ImportUsersServer) error {
+	// The options of the import are taken from the first message, it can also carry the first row.
+	first, err := stream.Recv()
+	if err == io.EOF {
+		first = &pb_user.UserImportRequest{}
+	} else if err != nil {
+		return err
+	}
+	data, err := a.commandHandler.ImportUsers(stream.Context(), *dto.NewUserImport(first).ToEntity(), dto.NewUserImportRows(first, stream.Recv).Next)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(dto.NewUserImportResultFromEntities(data).ToPbs())
+}
+
+// ChangeUserStatus sends the given status transition to the application layer for moving the user through its lifecycle.
+func (a *Grpc) ChangeUserStatus(ctx context.Context, userStatusChange *pb_user.UserStatusChange) (*pb_user.User, error) {
+	data, err := a.commandHandler.ChangeUserStatus(ctx, *dto.NewUserStatusChange(userStatusChange).ToEntity())
+	return dto.NewUserFromEntity(data).ToPb(), err
+}
+
+// GetUserStatusHistory returns the status transitions of the user that match the given filter.
+func (a *Grpc) GetUserStatusHistory(ctx context.Context, filter *pb_user.UserStatusHistoryFilter) (*pb_user.UserStatusHistories, error) {
+	userStatusHistories, err := a.queryHandler.GetUserStatusHistory(ctx, *dto.NewUserStatusHistoryFilter(filter).ToEntity())
+	return dto.NewUserStatusHistoryFromEntities(userStatusHistories).ToPbs(), err
+}
+
+// RecordUserActivity sends the given login or activity of the user to the application layer for updating the activity times.
+func (a *Grpc) RecordUserActivity(ctx context.Context, userActivity *pb_user.UserActivity) (*pb_user.UserActivityResult, error) {
+	err := a.commandHandler.RecordUserActivity(ctx, *dto.NewUserActivity(userActivity).ToEntity())
+	return &pb_user.UserActivityResult{}, err
+}
+
+// RecordUserLogin sends the given authentication attempt of the user to the application layer for the login history.
+func (a *Grpc) RecordUserLogin(ctx context.Context, userLoginHistory *pb_user.UserLoginHistory) (*pb_user.UserLoginHistory, error) {
+	data, err := a.commandHandler.RecordUserLogin(ctx, *dto.NewUserLoginHistory(userLoginHistory).ToEntity())
+	return dto.NewUserLoginHistoryFromEntity(data).ToPb(), err
+}
+
+// GetLoginHistory returns the authentication attempts of the user that match the given filter.
+func (a *Grpc) GetLoginHistory(ctx context.Context, filter *pb_user.UserLoginHistoryFilter) (*pb_user.UserLoginHistories, error) {
+	userLoginHistories, err := a.queryHandler.GetLoginHistory(ctx, *dto.NewUserLoginHistoryFilter(filter).ToEntity())
+	return dto.NewUserLoginHistoryFromEntities(userLoginHistories).ToPbs(), err
+}
+
+// GetAuditTrail returns the audit trail entries that match the given filter.
+func (a *Grpc) GetAuditTrail(ctx context.Context, filter *pb_user.AuditFilter) (*pb_user.AuditEntries, error) {
+	auditEntries, err := a.queryHandler.GetAuditTrail(ctx, *dto.NewAuditFilter(filter).ToEntity())
+	return dto.NewAuditEntryFromEntities(auditEntries).ToPbs(), err
+}
+
+// VerifyAuditTrail checks that no entry of the audit trail is changed or removed.
+func (a *Grpc) VerifyAuditTrail(ctx context.Context, request *pb_user.AuditTrailVerificationRequest) (*pb_user.AuditTrailVerification, error) {
+	auditTrailVerification, err := a.queryHandler.VerifyAuditTrail(ctx)
+	return dto.NewAuditTrailVerificationFromEntity(auditTrailVerification).ToPb(), err
+}
+
+// GetUserReadAccesses returns the reads of the personal data of the user that match the given filter.
+func (a *Grpc) GetUserReadAccesses(ctx context.Context, filter *pb_user.UserReadAccessFilter) (*pb_user.UserReadAccesses, error) {
+	userReadAccesses, err := a.queryHandler.GetUserReadAccesses(ctx, *dto.NewUserReadAccessFilter(filter).ToEntity())
+	return dto.NewUserReadAccessFromEntities(userReadAccesses).ToPbs(), err
+}
+
+// GetDeadLetters returns the dead-lettered messages of the channel that match the given filter.
+func (a *Grpc) GetDeadLetters(ctx context.Context, filter *pb_user.DeadLetterFilter) (*pb_user.DeadLetters, error) {
+	deadLetters, err := a.queryHandler.GetDeadLetters(ctx, *dto.NewDeadLetterFilter(filter).ToEntity())
+	return dto.NewDeadLetterFromEntities(deadLetters).ToPbs(), err
+}
+
+// ReplayDeadLetters sends the given dead-lettered messages to the application layer for sending them back to their channel.
+func (a *Grpc) ReplayDeadLetters(ctx context.Context, filter *pb_user.DeadLetterFilter) (*pb_user.DeadLetterResult, error) {
+	count, err := a.commandHandler.ReplayDeadLetters(ctx, *dto.NewDeadLetterFilter(filter).ToEntity())
+	return &pb_user.DeadLetterResult{Count: count}, err
+}
+
+// PurgeDeadLetters sends the given dead-lettered messages to the application layer for deleting them.
+func (a *Grpc) PurgeDeadLetters(ctx context.Context, filter *pb_user.DeadLetterFilter) (*pb_user.DeadLetterResult, error) {
+	count, err := a.commandHandler.PurgeDeadLetters(ctx, *dto.NewDeadLetterFilter(filter).ToEntity())
+	return &pb_user.DeadLetterResult{Count: count}, err
+}
+
+// userDataExportChunkSize is the size of the bundle part sent in each message of the export stream.
+const userDataExportChunkSize = 64 * 1024
+
+// ExportUserData streams everything the service holds about the given user as a JSON or ZIP bundle.
+func (a *Grpc) ExportUserData(request *pb_user.UserDataExportRequest, stream pb_user.UserSvc_ExportUserDataServer) error {
+	data, err := a.queryHandler.ExportUserData(stream.Context(), tuuid.FromString(request.UserId))
+	if err != nil {
+		return err
+	}
+	chunks, err := dto.NewUserDataExportFromEntity(data, request.Format).ToPbs(userDataExportChunkSize)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userExportChunkSize is the size of the buffered export data sent in each message of the users export stream.
+const userExportChunkSize = 64 * 1024
+
+// userExportStream is an io.Writer that sends each write as a message of the users export stream.
+type userExportStream struct {
+	stream      pb_user.UserSvc_ExportUsersServer
+	contentType string
+}
+
+// Write sends the given data as a chunk of the users export stream.
+func (s *userExportStream) Write(data []byte) (int, error) {
+	if err := s.stream.Send(&pb_user.UserExportChunk{ContentType: s.contentType, Data: data}); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// ExportUsers streams the selected columns of the users that match the given filter as CSV or NDJSON.
+// The users are written while they are read from the database, so the memory use does not grow with the number of the users.
+func (a *Grpc) ExportUsers(request *pb_user.UserExportRequest, stream pb_user.UserSvc_ExportUsersServer) error {
+	exportStream := &userExportStream{stream: stream}
+	writer := bufio.NewWriterSize(exportStream, userExportChunkSize)
+	userExport, err := dto.NewUserExport(writer, request.Format, request.Columns)
+	if err != nil {
+		return err
+	}
+	exportStream.contentType = userExport.ContentType()
+	filter := request.Filter
+	if filter == nil {
+		filter = &pb_user.UserFilter{}
+	}
+	err = a.queryHandler.ExportUsers(stream.Context(), *dto.NewUserFilter(filter).ToEntity(), userExport.Write)
+	if err != nil {
+		return err
+	}
+	if err := userExport.Flush(); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,118 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditEntry is a struct that represents the dto of an audit trail entry.
+type AuditEntry struct {
+	proto *pb.AuditEntry
+}
+
+// NewAuditEntry creates a new *AuditEntry.
+func NewAuditEntry(pb *pb.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the AuditEntry.
+func (s *AuditEntry) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"Seq: %v, "+
+		"ActorId: %v, "+
+		"AuditAction: %v, "+
+		"TargetId: %v, "+
+		"RequestId: %v, "+
+		"Diff: %v, "+
+		"DiffHash: %v, "+
+		"PrevHash: %v, "+
+		"Hash: %v",
+		s.proto.Id,
+		s.proto.Seq,
+		s.proto.ActorId,
+		s.proto.AuditAction,
+		s.proto.TargetId,
+		s.proto.RequestId,
+		s.proto.Diff,
+		s.proto.DiffHash,
+		s.proto.PrevHash,
+		s.proto.Hash)
+}
+
+// NewAuditEntryFromEntity creates a new *AuditEntry from entity.
+func NewAuditEntryFromEntity(entity me.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		&pb.AuditEntry{
+			Id:          entity.Id.String(),
+			Seq:         entity.Seq,
+			ActorId:     entity.ActorId.String(),
+			AuditAction: pb.AuditAction(entity.AuditAction),
+			TargetId:    entity.TargetId.String(),
+			RequestId:   entity.RequestId,
+			Diff:        entity.Diff,
+			DiffHash:    entity.DiffHash,
+			PrevHash:    entity.PrevHash,
+			Hash:        entity.Hash,
+			CreatedAt:   timestamppb.New(entity.CreatedAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the AuditEntry.
+func (s *AuditEntry) ToPb() *pb.AuditEntry {
+	return s.proto
+}
+
+// ToEntity returns a entity representation of the AuditEntry.
+func (s *AuditEntry) ToEntity() *me.AuditEntry {
+	return &me.AuditEntry{
+		Id:          tuuid.FromString(s.proto.Id),
+		Seq:         s.proto.Seq,
+		ActorId:     tuuid.FromString(s.proto.ActorId),
+		AuditAction: mo.AuditAction(s.proto.AuditAction),
+		TargetId:    tuuid.FromString(s.proto.TargetId),
+		RequestId:   s.proto.RequestId,
+		Diff:        s.proto.Diff,
+		DiffHash:    s.proto.DiffHash,
+		PrevHash:    s.proto.PrevHash,
+		Hash:        s.proto.Hash,
+		CreatedAt:   s.proto.CreatedAt.AsTime(),
+	}
+}
+
+type AuditEntries struct {
+	AuditEntries []*AuditEntry `json:"audit_entries"`
+	TotalRows    int64         `json:"total_rows"`
+}
+
+// NewAuditEntryFromEntities creates a new []*AuditEntry from entities.
+func NewAuditEntryFromEntities(entities me.AuditEntries) AuditEntries {
+	auditEntries := make([]*AuditEntry, len(entities.AuditEntries))
+	for i, entity := range entities.AuditEntries {
+		auditEntries[i] = NewAuditEntryFromEntity(entity)
+	}
+
+	return AuditEntries{
+		AuditEntries: auditEntries,
+		TotalRows:    entities.TotalRows,
+	}
+}
+
+// ToPbs returns a protobuf representation of the AuditEntries.
+func (s AuditEntries) ToPbs() *pb.AuditEntries {
+	auditEntries := make([]*pb.AuditEntry, len(s.AuditEntries))
+	for i, auditEntry := range s.AuditEntries {
+		auditEntries[i] = auditEntry.proto
+	}
+	return &pb.AuditEntries{
+		AuditEntries: auditEntries,
+		TotalRows:    s.TotalRows,
+	}
+}
@@ -0,0 +1,74 @@
+package presentation
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// AuditFilter is a struct that represents the filter dto of the audit trail.
+type AuditFilter struct {
+	proto *pb.AuditFilter
+}
+
+// NewAuditFilter creates a new *AuditFilter.
+func NewAuditFilter(pb *pb.AuditFilter) *AuditFilter {
+	return &AuditFilter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the AuditFilter.
+func (s *AuditFilter) String() string {
+	return fmt.Sprintf("ActorId: %v, "+
+		"TargetId: %v, "+
+		"AuditAction: %v, "+
+		"CreatedAtFrom: %v, "+
+		"CreatedAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.proto.ActorId,
+		s.proto.TargetId,
+		s.proto.AuditAction,
+		s.proto.CreatedAtFrom,
+		s.proto.CreatedAtTo,
+		s.proto.Limit,
+		s.proto.Offset)
+}
+
+// ToEntity returns a entity representation of the AuditFilter.
+func (s *AuditFilter) ToEntity() *me.AuditFilter {
+	auditAction := 0
+	if s.proto.AuditAction != nil {
+		auditAction = int(*s.proto.AuditAction)
+	}
+	createdAtFrom := time.Time{}
+	if s.proto.CreatedAtFrom != nil {
+		createdAtFrom = s.proto.CreatedAtFrom.AsTime()
+	}
+	createdAtTo := time.Time{}
+	if s.proto.CreatedAtTo != nil {
+		createdAtTo = s.proto.CreatedAtTo.AsTime()
+	}
+	limit := 0
+	if s.proto.Limit != nil {
+		limit = int(*s.proto.Limit)
+	}
+	offset := 0
+	if s.proto.Offset != nil {
+		offset = int(*s.proto.Offset)
+	}
+	return &me.AuditFilter{
+		ActorId:       tuuid.FromString(s.proto.ActorId),
+		TargetId:      tuuid.FromString(s.proto.TargetId),
+		AuditAction:   mo.AuditAction(auditAction),
+		CreatedAtFrom: createdAtFrom,
+		CreatedAtTo:   createdAtTo,
+		Limit:         limit,
+		Offset:        offset,
+	}
+}
@@ -0,0 +1,46 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// AuditTrailVerification is a struct that represents the dto of the outcome of a verification of the audit trail.
+type AuditTrailVerification struct {
+	proto *pb.AuditTrailVerification
+}
+
+// NewAuditTrailVerification creates a new *AuditTrailVerification.
+func NewAuditTrailVerification(pb *pb.AuditTrailVerification) *AuditTrailVerification {
+	return &AuditTrailVerification{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the AuditTrailVerification.
+func (s *AuditTrailVerification) String() string {
+	return fmt.Sprintf("Valid: %v, "+
+		"VerifiedEntries: %v, "+
+		"BrokenSeq: %v",
+		s.proto.Valid,
+		s.proto.VerifiedEntries,
+		s.proto.BrokenSeq)
+}
+
+// NewAuditTrailVerificationFromEntity creates a new *AuditTrailVerification from entity.
+func NewAuditTrailVerificationFromEntity(entity me.AuditTrailVerification) *AuditTrailVerification {
+	return &AuditTrailVerification{
+		&pb.AuditTrailVerification{
+			Valid:           entity.Valid,
+			VerifiedEntries: entity.VerifiedEntries,
+			BrokenSeq:       entity.BrokenSeq,
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the AuditTrailVerification.
+func (s *AuditTrailVerification) ToPb() *pb.AuditTrailVerification {
+	return s.proto
+}
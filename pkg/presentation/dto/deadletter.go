@@ -0,0 +1,88 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeadLetter is a struct that represents the dto of a dead-lettered event bus message.
+type DeadLetter struct {
+	proto *pb.DeadLetter
+}
+
+// NewDeadLetter creates a new *DeadLetter.
+func NewDeadLetter(pb *pb.DeadLetter) *DeadLetter {
+	return &DeadLetter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the DeadLetter.
+func (s *DeadLetter) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"ChannelName: %v, "+
+		"MessageId: %v, "+
+		"Message: %v, "+
+		"Error: %v, "+
+		"Attempts: %v",
+		s.proto.Id,
+		s.proto.ChannelName,
+		s.proto.MessageId,
+		s.proto.Message,
+		s.proto.Error,
+		s.proto.Attempts)
+}
+
+// NewDeadLetterFromEntity creates a new *DeadLetter from entity.
+func NewDeadLetterFromEntity(entity me.DeadLetter) *DeadLetter {
+	return &DeadLetter{
+		&pb.DeadLetter{
+			Id:             entity.Id,
+			ChannelName:    entity.ChannelName,
+			MessageId:      entity.MessageId,
+			Message:        entity.Message,
+			Error:          entity.Error,
+			Attempts:       entity.Attempts,
+			ReceivedAt:     timestamppb.New(entity.ReceivedAt),
+			DeadLetteredAt: timestamppb.New(entity.DeadLetteredAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the DeadLetter.
+func (s *DeadLetter) ToPb() *pb.DeadLetter {
+	return s.proto
+}
+
+type DeadLetters struct {
+	DeadLetters []*DeadLetter `json:"dead_letters"`
+	TotalRows   int64         `json:"total_rows"`
+}
+
+// NewDeadLetterFromEntities creates a new []*DeadLetter from entities.
+func NewDeadLetterFromEntities(entities me.DeadLetters) DeadLetters {
+	deadLetters := make([]*DeadLetter, len(entities.DeadLetters))
+	for i, entity := range entities.DeadLetters {
+		deadLetters[i] = NewDeadLetterFromEntity(entity)
+	}
+
+	return DeadLetters{
+		DeadLetters: deadLetters,
+		TotalRows:   entities.TotalRows,
+	}
+}
+
+// ToPbs returns a protobuf representation of the DeadLetters.
+func (s DeadLetters) ToPbs() *pb.DeadLetters {
+	deadLetters := make([]*pb.DeadLetter, len(s.DeadLetters))
+	for i, deadLetter := range s.DeadLetters {
+		deadLetters[i] = deadLetter.proto
+	}
+	return &pb.DeadLetters{
+		DeadLetters: deadLetters,
+		TotalRows:   s.TotalRows,
+	}
+}
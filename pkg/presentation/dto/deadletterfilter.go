@@ -0,0 +1,54 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// DeadLetterFilter is a struct that represents the filter dto of the dead-lettered messages of a channel.
+type DeadLetterFilter struct {
+	proto *pb.DeadLetterFilter
+}
+
+// NewDeadLetterFilter creates a new *DeadLetterFilter.
+func NewDeadLetterFilter(pb *pb.DeadLetterFilter) *DeadLetterFilter {
+	return &DeadLetterFilter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the DeadLetterFilter.
+func (s *DeadLetterFilter) String() string {
+	return fmt.Sprintf("ChannelName: %v, "+
+		"Ids: %v, "+
+		"AfterId: %v, "+
+		"Limit: %v",
+		s.proto.ChannelName,
+		s.proto.Ids,
+		s.proto.AfterId,
+		s.proto.Limit)
+}
+
+// ToEntity returns a entity representation of the DeadLetterFilter.
+func (s *DeadLetterFilter) ToEntity() *me.DeadLetterFilter {
+	ids := []string{}
+	if s.proto.Ids != nil {
+		ids = s.proto.Ids
+	}
+	afterId := ""
+	if s.proto.AfterId != nil {
+		afterId = *s.proto.AfterId
+	}
+	limit := 0
+	if s.proto.Limit != nil {
+		limit = int(*s.proto.Limit)
+	}
+	return &me.DeadLetterFilter{
+		ChannelName: s.proto.ChannelName,
+		Ids:         ids,
+		AfterId:     afterId,
+		Limit:       limit,
+	}
+}
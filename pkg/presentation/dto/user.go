@@ -2,6 +2,7 @@ package presentation
 
 import (
 	"fmt"
+	"time"
 
 	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
 	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
@@ -58,9 +59,19 @@ func NewUserFromEntity(entity me.User) *User {
 			FirstName:  entity.FirstName,
 			LastName:   entity.LastName,
 
+			SuspendedUntil: toTimestamp(entity.SuspendedUntil),
+			ExpiresAt:      toTimestamp(entity.ExpiresAt),
+
 			// Only for view
 			CreatedAt: timestamppb.New(entity.CreatedAt),
 			UpdatedAt: timestamppb.New(entity.UpdatedAt),
+
+			LastLoginAt:    toTimestamp(entity.LastLoginAt),
+			LastActivityAt: toTimestamp(entity.LastActivityAt),
+
+			DeletedAt: toTimestamp(entity.DeletedAt),
+			DeletedBy: entity.DeletedBy.String(),
+			ErasedAt:  toTimestamp(entity.ErasedAt),
 		},
 	}
 }
@@ -83,13 +94,17 @@ func (s *User) ToEntity() *me.User {
 			Tags:       s.proto.Tags,
 			FirstName:  s.proto.FirstName,
 			LastName:   s.proto.LastName,
+
+			SuspendedUntil: fromTimestamp(s.proto.SuspendedUntil),
+			ExpiresAt:      fromTimestamp(s.proto.ExpiresAt),
 		},
 	}
 }
 
 type Users struct {
-	Users     []*User `json:"users"`
-	TotalRows int64   `json:"total_rows"`
+	Users         []*User `json:"users"`
+	TotalRows     int64   `json:"total_rows"`
+	NextPageToken string  `json:"next_page_token"`
 }
 
 // NewUsersFromEntities creates a new []*User from entities.
@@ -100,8 +115,9 @@ func NewUserFromEntities(entities me.Users) Users {
 	}
 
 	return Users{
-		Users:     users,
-		TotalRows: entities.TotalRows,
+		Users:         users,
+		TotalRows:     entities.TotalRows,
+		NextPageToken: entities.NextPageToken,
 	}
 }
 
@@ -112,7 +128,24 @@ func (s Users) ToPbs() *pb.Users {
 		users[i] = user.proto
 	}
 	return &pb.Users{
-		Users:     users,
-		TotalRows: s.TotalRows,
+		Users:         users,
+		TotalRows:     s.TotalRows,
+		NextPageToken: s.NextPageToken,
+	}
+}
+
+// toTimestamp returns nil for the zero time so that it is not sent.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp returns the zero time for the unset timestamp.
+func fromTimestamp(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
 	}
+	return t.AsTime()
 }
@@ -0,0 +1,41 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserActivity is a struct that represents the dto of an activity of a user.
+type UserActivity struct {
+	proto *pb.UserActivity
+}
+
+// NewUserActivity creates a new *UserActivity.
+func NewUserActivity(pb *pb.UserActivity) *UserActivity {
+	return &UserActivity{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserActivity.
+func (s *UserActivity) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"UserActivityType: %v, "+
+		"OccurredAt: %v",
+		s.proto.UserId,
+		s.proto.UserActivityType,
+		s.proto.OccurredAt)
+}
+
+// ToEntity returns a entity representation of the UserActivity.
+func (s *UserActivity) ToEntity() *me.UserActivity {
+	return &me.UserActivity{
+		UserId:           tuuid.FromString(s.proto.UserId),
+		UserActivityType: mo.UserActivityType(s.proto.UserActivityType),
+		OccurredAt:       fromTimestamp(s.proto.OccurredAt),
+	}
+}
@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserBatch is a struct that represents the dto of a command applied to many users at once.
+type UserBatch struct {
+	proto *pb.UserBatchRequest
+}
+
+// NewUserBatch creates a new *UserBatch.
+func NewUserBatch(pb *pb.UserBatchRequest) *UserBatch {
+	return &UserBatch{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserBatch.
+func (s *UserBatch) String() string {
+	return fmt.Sprintf("UserIds: %v, "+
+		"Filter: %v, "+
+		"Mode: %v, "+
+		"UserStatus: %v, "+
+		"Reason: %v, "+
+		"SuspendedUntil: %v, "+
+		"Role: %v, "+
+		"Tags: %v",
+		len(s.proto.UserIds),
+		s.proto.Filter,
+		s.proto.Mode,
+		s.proto.UserStatus,
+		s.proto.Reason,
+		s.proto.SuspendedUntil,
+		s.proto.Role,
+		s.proto.Tags)
+}
+
+// ToEntity returns a entity representation of the UserBatch.
+func (s *UserBatch) ToEntity() *me.UserBatch {
+	userIds := make([]uuid.UUID, len(s.proto.UserIds))
+	for i, userId := range s.proto.UserIds {
+		userIds[i] = tuuid.FromString(userId)
+	}
+	var userFilter *me.UserFilter
+	if s.proto.Filter != nil {
+		userFilter = NewUserFilter(s.proto.Filter).ToEntity()
+	}
+	userBatch := me.NewUserBatch(userIds, userFilter, mo.UserBatchMode(s.proto.Mode))
+	userBatch.UserStatus = mo.UserStatus(s.proto.UserStatus)
+	userBatch.Reason = s.proto.Reason
+	userBatch.SuspendedUntil = fromTimestamp(s.proto.SuspendedUntil)
+	userBatch.Role = s.proto.Role
+	userBatch.Tags = s.proto.Tags
+	return userBatch
+}
@@ -0,0 +1,84 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserBatchResult is a struct that represents the dto of the outcome of an item of a user batch command.
+type UserBatchResult struct {
+	proto *pb.UserBatchResult
+}
+
+// NewUserBatchResult creates a new *UserBatchResult.
+func NewUserBatchResult(pb *pb.UserBatchResult) *UserBatchResult {
+	return &UserBatchResult{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserBatchResult.
+func (s *UserBatchResult) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"User: %v, "+
+		"Error: %v",
+		s.proto.UserId,
+		s.proto.User,
+		s.proto.Error)
+}
+
+// NewUserBatchResultFromEntity creates a new *UserBatchResult from entity.
+func NewUserBatchResultFromEntity(entity me.UserBatchResult) *UserBatchResult {
+	var user *pb.User
+	if entity.User.Id != (uuid.UUID{}) {
+		user = NewUserFromEntity(entity.User).ToPb()
+	}
+	return &UserBatchResult{
+		&pb.UserBatchResult{
+			UserId: entity.UserId.String(),
+			User:   user,
+			Error:  entity.Error,
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserBatchResult.
+func (s *UserBatchResult) ToPb() *pb.UserBatchResult {
+	return s.proto
+}
+
+type UserBatchResults struct {
+	UserBatchResults []*UserBatchResult `json:"user_batch_results"`
+	Succeeded        int64              `json:"succeeded"`
+	Failed           int64              `json:"failed"`
+}
+
+// NewUserBatchResultFromEntities creates a new []*UserBatchResult from entities.
+func NewUserBatchResultFromEntities(entities me.UserBatchResults) UserBatchResults {
+	userBatchResults := make([]*UserBatchResult, len(entities.UserBatchResults))
+	for i, entity := range entities.UserBatchResults {
+		userBatchResults[i] = NewUserBatchResultFromEntity(entity)
+	}
+
+	return UserBatchResults{
+		UserBatchResults: userBatchResults,
+		Succeeded:        entities.Succeeded,
+		Failed:           entities.Failed,
+	}
+}
+
+// ToPbs returns a protobuf representation of the UserBatchResults.
+func (s UserBatchResults) ToPbs() *pb.UserBatchResults {
+	userBatchResults := make([]*pb.UserBatchResult, len(s.UserBatchResults))
+	for i, userBatchResult := range s.UserBatchResults {
+		userBatchResults[i] = userBatchResult.proto
+	}
+	return &pb.UserBatchResults{
+		UserBatchResults: userBatchResults,
+		Succeeded:        s.Succeeded,
+		Failed:           s.Failed,
+	}
+}
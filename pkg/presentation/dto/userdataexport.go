@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserDataExport is a struct that represents the dto of the bundle of everything the service holds about a user.
+type UserDataExport struct {
+	entity me.UserDataExport
+	format pb.UserDataExportFormat
+}
+
+// NewUserDataExportFromEntity creates a new *UserDataExport from entity in the given format.
+func NewUserDataExportFromEntity(entity me.UserDataExport, format pb.UserDataExportFormat) *UserDataExport {
+	return &UserDataExport{
+		entity: entity,
+		format: format,
+	}
+}
+
+// String returns a string representation of the UserDataExport.
+func (s *UserDataExport) String() string {
+	return fmt.Sprintf("UserDataExport: %v, "+
+		"Format: %v",
+		s.entity.String(),
+		s.format)
+}
+
+// FileName returns the file name of the bundle.
+func (s *UserDataExport) FileName() string {
+	if s.format == pb.UserDataExportFormat_UserDataExportFormatZIP {
+		return "user-" + s.entity.User.Id.String() + ".zip"
+	}
+	return "user-" + s.entity.User.Id.String() + ".json"
+}
+
+// ContentType returns the media type of the bundle.
+func (s *UserDataExport) ContentType() string {
+	if s.format == pb.UserDataExportFormat_UserDataExportFormatZIP {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+// ToBytes returns the bundle, a single JSON document or a ZIP archive with a JSON document per dataset.
+func (s *UserDataExport) ToBytes() ([]byte, error) {
+	if s.format != pb.UserDataExportFormat_UserDataExportFormatZIP {
+		return json.MarshalIndent(s.entity, "", "  ")
+	}
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "user.json", data: s.entity.User},
+		{name: "user_status_histories.json", data: s.entity.UserStatusHistories},
+		{name: "user_login_histories.json", data: s.entity.UserLoginHistories},
+		{name: "audit_entries.json", data: s.entity.AuditEntries},
+		{name: "user_read_accesses.json", data: s.entity.UserReadAccesses},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: s.entity.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ToPbs returns the bundle split into the protobuf chunks of the given size for streaming.
+func (s *UserDataExport) ToPbs(chunkSize int) ([]*pb.UserDataExportChunk, error) {
+	data, err := s.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]*pb.UserDataExportChunk, 0, len(data)/chunkSize+1)
+	for start := 0; start < len(data); start += chunkSize {
+		end := start + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, &pb.UserDataExportChunk{
+			FileName:    s.FileName(),
+			ContentType: s.ContentType(),
+			Data:        data[start:end],
+		})
+	}
+	return chunks, nil
+}
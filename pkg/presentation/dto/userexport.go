@@ -0,0 +1,159 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserExportColumns are the columns of the users export, the keys are the CSV header and the NDJSON keys.
+var UserExportColumns = map[string]func(entity me.User) interface{}{
+	"id":               func(entity me.User) interface{} { return entity.Id.String() },
+	"username":         func(entity me.User) interface{} { return entity.UserName },
+	"email":            func(entity me.User) interface{} { return entity.Email },
+	"role":             func(entity me.User) interface{} { return entity.Role },
+	"user_type":        func(entity me.User) interface{} { return int(entity.UserType) },
+	"user_status":      func(entity me.User) interface{} { return int(entity.UserStatus) },
+	"tags":             func(entity me.User) interface{} { return entity.Tags },
+	"first_name":       func(entity me.User) interface{} { return entity.FirstName },
+	"last_name":        func(entity me.User) interface{} { return entity.LastName },
+	"suspended_until":  func(entity me.User) interface{} { return entity.SuspendedUntil },
+	"expires_at":       func(entity me.User) interface{} { return entity.ExpiresAt },
+	"created_at":       func(entity me.User) interface{} { return entity.CreatedAt },
+	"updated_at":       func(entity me.User) interface{} { return entity.UpdatedAt },
+	"last_login_at":    func(entity me.User) interface{} { return entity.LastLoginAt },
+	"last_activity_at": func(entity me.User) interface{} { return entity.LastActivityAt },
+	"deleted_at":       func(entity me.User) interface{} { return entity.DeletedAt },
+}
+
+// DefaultUserExportColumns are the columns of the users export if no column is selected, they can be imported back.
+var DefaultUserExportColumns = []string{"id", "username", "email", "role", "user_type", "user_status", "tags", "first_name", "last_name", "created_at", "updated_at"}
+
+// UserExport is a struct that represents the dto of a users export written as CSV or NDJSON.
+type UserExport struct {
+	writer    io.Writer
+	csvWriter *csv.Writer
+	format    pb.UserExportFormat
+	columns   []string
+}
+
+// NewUserExport creates a new *UserExport that writes the given columns of the users to the given writer.
+// The CSV header is written at once, an unknown column returns an error.
+func NewUserExport(writer io.Writer, format pb.UserExportFormat, columns []string) (*UserExport, error) {
+	if len(columns) == 0 {
+		columns = DefaultUserExportColumns
+	}
+	for _, column := range columns {
+		if _, ok := UserExportColumns[column]; !ok {
+			return nil, mo.ErrorUserExportColumnIsNotValid
+		}
+	}
+	s := &UserExport{
+		writer:  writer,
+		format:  format,
+		columns: columns,
+	}
+	if format == pb.UserExportFormat_UserExportFormatNDJSON {
+		return s, nil
+	}
+	s.csvWriter = csv.NewWriter(writer)
+	if err := s.csvWriter.Write(columns); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// String returns a string representation of the UserExport.
+func (s *UserExport) String() string {
+	return fmt.Sprintf("Format: %v, "+
+		"Columns: %v",
+		s.format,
+		s.columns)
+}
+
+// ContentType returns the media type of the export.
+func (s *UserExport) ContentType() string {
+	if s.format == pb.UserExportFormat_UserExportFormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Write writes the selected columns of the given user as a CSV record or a JSON line.
+func (s *UserExport) Write(entity me.User) error {
+	if s.csvWriter != nil {
+		record := make([]string, len(s.columns))
+		for i, column := range s.columns {
+			record[i] = toCsvValue(UserExportColumns[column](entity))
+		}
+		return s.csvWriter.Write(record)
+	}
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range s.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(toJsonValue(UserExportColumns[column](entity)))
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	_, err := s.writer.Write(line.Bytes())
+	return err
+}
+
+// Flush writes the buffered CSV records to the writer.
+func (s *UserExport) Flush() error {
+	if s.csvWriter == nil {
+		return nil
+	}
+	s.csvWriter.Flush()
+	return s.csvWriter.Error()
+}
+
+// toCsvValue returns the CSV field of the given value, the tags are separated by semicolons and a zero time is empty.
+func toCsvValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ";")
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toJsonValue returns the JSON value of the given value, a zero time is null.
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []string:
+		if v == nil {
+			return []string{}
+		}
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return v
+	}
+}
@@ -38,11 +38,19 @@ func (s *UserFilter) String() string {
 		"CreatedAtTo: %v, "+
 		"UpdatedAtFrom: %v, "+
 		"UpdatedAtTo: %v, "+
+		"SuspendedUntilBefore: %v, "+
+		"ExpiresAtBefore: %v, "+
+		"LastLoginBefore: %v, "+
+		"LastLoginAfter: %v, "+
+		"IncludeDeleted: %v, "+
+		"OnlyDeleted: %v, "+
 		"SearchText: %v, "+
 		"SortType: %v, "+
 		"SortField: %v, "+
 		"Limit: %v, "+
-		"Offset: %v",
+		"Offset: %v, "+
+		"PageToken: %v, "+
+		"CountMode: %v",
 		s.proto.Id,
 		s.proto.Username,
 		s.proto.Email,
@@ -55,11 +63,19 @@ func (s *UserFilter) String() string {
 		s.proto.CreatedAtTo,
 		s.proto.UpdatedAtFrom,
 		s.proto.UpdatedAtTo,
+		s.proto.SuspendedUntilBefore,
+		s.proto.ExpiresAtBefore,
+		s.proto.LastLoginBefore,
+		s.proto.LastLoginAfter,
+		s.proto.IncludeDeleted,
+		s.proto.OnlyDeleted,
 		s.proto.SearchText,
 		s.proto.SortType,
 		s.proto.SortField,
 		s.proto.Limit,
-		s.proto.Offset)
+		s.proto.Offset,
+		s.proto.PageToken,
+		s.proto.CountMode)
 }
 
 // NewUserFilterFromEntity creates a new *UserFilter from entity.
@@ -76,11 +92,19 @@ func NewUserFilterFromEntity(entity me.UserFilter) *UserFilter {
 	createdAtTo := timestamppb.New(entity.CreatedAtTo)
 	updatedAtFrom := timestamppb.New(entity.UpdatedAtFrom)
 	updatedAtTo := timestamppb.New(entity.UpdatedAtTo)
+	suspendedUntilBefore := timestamppb.New(entity.SuspendedUntilBefore)
+	expiresAtBefore := timestamppb.New(entity.ExpiresAtBefore)
+	lastLoginBefore := timestamppb.New(entity.LastLoginBefore)
+	lastLoginAfter := timestamppb.New(entity.LastLoginAfter)
+	includeDeleted := entity.IncludeDeleted
+	onlyDeleted := entity.OnlyDeleted
 	searchText := entity.SearchText
 	sortType := entity.SortType
 	sortField := pb.UserSortField(entity.SortField)
 	limit := int32(entity.Limit)
 	offset := int32(entity.Offset)
+	pageToken := entity.PageToken
+	countMode := pb.UserCountMode(entity.CountMode)
 	return &UserFilter{
 		&pb.UserFilter{
 			Id:            &id,
@@ -95,11 +119,23 @@ func NewUserFilterFromEntity(entity me.UserFilter) *UserFilter {
 			CreatedAtTo:   createdAtTo,
 			UpdatedAtFrom: updatedAtFrom,
 			UpdatedAtTo:   updatedAtTo,
-			SearchText:    &searchText,
-			SortType:      &sortType,
-			SortField:     &sortField,
-			Limit:         &limit,
-			Offset:        &offset,
+
+			SuspendedUntilBefore: suspendedUntilBefore,
+			ExpiresAtBefore:      expiresAtBefore,
+			LastLoginBefore:      lastLoginBefore,
+			LastLoginAfter:       lastLoginAfter,
+
+			IncludeDeleted: &includeDeleted,
+			OnlyDeleted:    &onlyDeleted,
+
+			SearchText: &searchText,
+			SortType:   &sortType,
+			SortField:  &sortField,
+			Limit:      &limit,
+			Offset:     &offset,
+
+			PageToken: &pageToken,
+			CountMode: &countMode,
 		},
 	}
 }
@@ -154,6 +190,30 @@ func (s *UserFilter) ToEntity() *me.UserFilter {
 	if s.proto.UpdatedAtTo != nil {
 		updatedAtTo = s.proto.UpdatedAtTo.AsTime()
 	}
+	suspendedUntilBefore := time.Time{}
+	if s.proto.SuspendedUntilBefore != nil {
+		suspendedUntilBefore = s.proto.SuspendedUntilBefore.AsTime()
+	}
+	expiresAtBefore := time.Time{}
+	if s.proto.ExpiresAtBefore != nil {
+		expiresAtBefore = s.proto.ExpiresAtBefore.AsTime()
+	}
+	lastLoginBefore := time.Time{}
+	if s.proto.LastLoginBefore != nil {
+		lastLoginBefore = s.proto.LastLoginBefore.AsTime()
+	}
+	lastLoginAfter := time.Time{}
+	if s.proto.LastLoginAfter != nil {
+		lastLoginAfter = s.proto.LastLoginAfter.AsTime()
+	}
+	includeDeleted := false
+	if s.proto.IncludeDeleted != nil {
+		includeDeleted = *s.proto.IncludeDeleted
+	}
+	onlyDeleted := false
+	if s.proto.OnlyDeleted != nil {
+		onlyDeleted = *s.proto.OnlyDeleted
+	}
 	searchText := ""
 	if s.proto.SearchText != nil {
 		searchText = string(*s.proto.SearchText)
@@ -174,6 +234,14 @@ func (s *UserFilter) ToEntity() *me.UserFilter {
 	if s.proto.Offset != nil {
 		offset = int(*s.proto.Offset)
 	}
+	pageToken := ""
+	if s.proto.PageToken != nil {
+		pageToken = *s.proto.PageToken
+	}
+	countMode := 0
+	if s.proto.CountMode != nil {
+		countMode = int(*s.proto.CountMode)
+	}
 	return &me.UserFilter{
 		Id:            id,
 		UserName:      userName,
@@ -187,10 +255,22 @@ func (s *UserFilter) ToEntity() *me.UserFilter {
 		CreatedAtTo:   createdAtTo,
 		UpdatedAtFrom: updatedAtFrom,
 		UpdatedAtTo:   updatedAtTo,
-		SearchText:    searchText,
-		SortType:      sortType,
-		SortField:     mo.UserSortField(sortField),
-		Limit:         limit,
-		Offset:        offset,
+
+		SuspendedUntilBefore: suspendedUntilBefore,
+		ExpiresAtBefore:      expiresAtBefore,
+		LastLoginBefore:      lastLoginBefore,
+		LastLoginAfter:       lastLoginAfter,
+
+		IncludeDeleted: includeDeleted,
+		OnlyDeleted:    onlyDeleted,
+
+		SearchText: searchText,
+		SortType:   sortType,
+		SortField:  mo.UserSortField(sortField),
+		Limit:      limit,
+		Offset:     offset,
+
+		PageToken: pageToken,
+		CountMode: mo.UserCountMode(countMode),
 	}
 }
@@ -0,0 +1,109 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserImportRow is a struct that represents the dto of a row of a user import.
+type UserImportRow struct {
+	proto *pb.UserImportRow
+}
+
+// NewUserImportRow creates a new *UserImportRow.
+func NewUserImportRow(pb *pb.UserImportRow) *UserImportRow {
+	return &UserImportRow{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserImportRow.
+func (s *UserImportRow) String() string {
+	return fmt.Sprintf("Line: %v, "+
+		"User: %v, "+
+		"Password: ***, "+
+		"Invite: %v",
+		s.proto.Line,
+		s.proto.User,
+		s.proto.Invite)
+}
+
+// ToPb returns a protobuf representation of the UserImportRow.
+func (s *UserImportRow) ToPb() *pb.UserImportRow {
+	return s.proto
+}
+
+// ToEntity returns a entity representation of the UserImportRow.
+func (s *UserImportRow) ToEntity() *me.UserImportRow {
+	user := me.NewEmptyUser()
+	if s.proto.User != nil {
+		user = NewUser(s.proto.User).ToEntity()
+	}
+	return me.NewUserImportRow(int(s.proto.Line), *user, s.proto.Password, s.proto.Invite)
+}
+
+// UserImport is a struct that represents the dto of the options of a user import stream, they are taken from its first message.
+type UserImport struct {
+	proto *pb.UserImportRequest
+}
+
+// NewUserImport creates a new *UserImport.
+func NewUserImport(pb *pb.UserImportRequest) *UserImport {
+	return &UserImport{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserImport.
+func (s *UserImport) String() string {
+	return fmt.Sprintf("Mode: %v, "+
+		"DryRun: %v",
+		s.proto.Mode,
+		s.proto.DryRun)
+}
+
+// ToEntity returns a entity representation of the UserImport.
+func (s *UserImport) ToEntity() *me.UserImport {
+	return me.NewUserImport(mo.UserImportMode(s.proto.Mode), s.proto.DryRun)
+}
+
+// UserImportRows is a struct that represents the dto of the rows of a user import stream, the messages are received while the rows are asked for.
+type UserImportRows struct {
+	pending *pb.UserImportRequest
+	recv    func() (*pb.UserImportRequest, error)
+}
+
+// NewUserImportRows creates a new *UserImportRows that receives the messages by recv after the given already received message.
+func NewUserImportRows(pending *pb.UserImportRequest, recv func() (*pb.UserImportRequest, error)) *UserImportRows {
+	return &UserImportRows{
+		pending: pending,
+		recv:    recv,
+	}
+}
+
+// Next returns the entities of the rows of at most limit next messages, it returns no row at the end of the stream.
+func (s *UserImportRows) Next(limit int) ([]me.UserImportRow, error) {
+	rows := make([]me.UserImportRow, 0, limit)
+	for len(rows) < limit {
+		request := s.pending
+		s.pending = nil
+		if request == nil {
+			var err error
+			request, err = s.recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if request.Row != nil {
+			rows = append(rows, *NewUserImportRow(request.Row).ToEntity())
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,90 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserImportResult is a struct that represents the dto of the outcome of a row of a user import.
+type UserImportResult struct {
+	proto *pb.UserImportResult
+}
+
+// NewUserImportResult creates a new *UserImportResult.
+func NewUserImportResult(pb *pb.UserImportResult) *UserImportResult {
+	return &UserImportResult{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserImportResult.
+func (s *UserImportResult) String() string {
+	return fmt.Sprintf("Line: %v, "+
+		"UserId: %v, "+
+		"Error: %v, "+
+		"Invited: %v",
+		s.proto.Line,
+		s.proto.UserId,
+		s.proto.Error,
+		s.proto.Invited)
+}
+
+// NewUserImportResultFromEntity creates a new *UserImportResult from entity.
+func NewUserImportResultFromEntity(entity me.UserImportResult) *UserImportResult {
+	userId := ""
+	if entity.UserId != (uuid.UUID{}) {
+		userId = entity.UserId.String()
+	}
+	return &UserImportResult{
+		&pb.UserImportResult{
+			Line:    int32(entity.Line),
+			UserId:  userId,
+			Error:   entity.Error,
+			Invited: entity.Invited,
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserImportResult.
+func (s *UserImportResult) ToPb() *pb.UserImportResult {
+	return s.proto
+}
+
+type UserImportResults struct {
+	UserImportResults []*UserImportResult `json:"user_import_results"`
+	Imported          int64               `json:"imported"`
+	Failed            int64               `json:"failed"`
+	DryRun            bool                `json:"dry_run"`
+}
+
+// NewUserImportResultFromEntities creates a new []*UserImportResult from entities.
+func NewUserImportResultFromEntities(entities me.UserImportResults) UserImportResults {
+	userImportResults := make([]*UserImportResult, len(entities.UserImportResults))
+	for i, entity := range entities.UserImportResults {
+		userImportResults[i] = NewUserImportResultFromEntity(entity)
+	}
+
+	return UserImportResults{
+		UserImportResults: userImportResults,
+		Imported:          entities.Imported,
+		Failed:            entities.Failed,
+		DryRun:            entities.DryRun,
+	}
+}
+
+// ToPbs returns a protobuf representation of the UserImportResults.
+func (s UserImportResults) ToPbs() *pb.UserImportResults {
+	userImportResults := make([]*pb.UserImportResult, len(s.UserImportResults))
+	for i, userImportResult := range s.UserImportResults {
+		userImportResults[i] = userImportResult.proto
+	}
+	return &pb.UserImportResults{
+		UserImportResults: userImportResults,
+		Imported:          s.Imported,
+		Failed:            s.Failed,
+		DryRun:            s.DryRun,
+	}
+}
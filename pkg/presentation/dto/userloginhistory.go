@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserLoginHistory is a struct that represents the dto of an authentication attempt of a user.
+type UserLoginHistory struct {
+	proto *pb.UserLoginHistory
+}
+
+// NewUserLoginHistory creates a new *UserLoginHistory.
+func NewUserLoginHistory(pb *pb.UserLoginHistory) *UserLoginHistory {
+	return &UserLoginHistory{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserLoginHistory.
+func (s *UserLoginHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"Success: %v, "+
+		"Reason: %v, "+
+		"IpAddress: %v, "+
+		"UserAgent: %v, "+
+		"Client: %v",
+		s.proto.Id,
+		s.proto.UserId,
+		s.proto.Success,
+		s.proto.Reason,
+		s.proto.IpAddress,
+		s.proto.UserAgent,
+		s.proto.Client)
+}
+
+// NewUserLoginHistoryFromEntity creates a new *UserLoginHistory from entity.
+func NewUserLoginHistoryFromEntity(entity me.UserLoginHistory) *UserLoginHistory {
+	return &UserLoginHistory{
+		&pb.UserLoginHistory{
+			Id:        entity.Id.String(),
+			UserId:    entity.UserId.String(),
+			Success:   entity.Success,
+			Reason:    entity.Reason,
+			IpAddress: entity.IpAddress,
+			UserAgent: entity.UserAgent,
+			Client:    entity.Client,
+
+			// Only for view
+			CreatedAt: timestamppb.New(entity.CreatedAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserLoginHistory.
+func (s *UserLoginHistory) ToPb() *pb.UserLoginHistory {
+	return s.proto
+}
+
+// ToEntity returns a entity representation of the UserLoginHistory.
+func (s *UserLoginHistory) ToEntity() *me.UserLoginHistory {
+	return &me.UserLoginHistory{
+		Id:        tuuid.FromString(s.proto.Id),
+		UserId:    tuuid.FromString(s.proto.UserId),
+		Success:   s.proto.Success,
+		Reason:    s.proto.Reason,
+		IpAddress: s.proto.IpAddress,
+		UserAgent: s.proto.UserAgent,
+		Client:    s.proto.Client,
+	}
+}
+
+type UserLoginHistories struct {
+	UserLoginHistories []*UserLoginHistory `json:"user_login_histories"`
+	TotalRows          int64               `json:"total_rows"`
+}
+
+// NewUserLoginHistoryFromEntities creates a new []*UserLoginHistory from entities.
+func NewUserLoginHistoryFromEntities(entities me.UserLoginHistories) UserLoginHistories {
+	userLoginHistories := make([]*UserLoginHistory, len(entities.UserLoginHistories))
+	for i, entity := range entities.UserLoginHistories {
+		userLoginHistories[i] = NewUserLoginHistoryFromEntity(entity)
+	}
+
+	return UserLoginHistories{
+		UserLoginHistories: userLoginHistories,
+		TotalRows:          entities.TotalRows,
+	}
+}
+
+// ToPbs returns a protobuf representation of the UserLoginHistories.
+func (s UserLoginHistories) ToPbs() *pb.UserLoginHistories {
+	userLoginHistories := make([]*pb.UserLoginHistory, len(s.UserLoginHistories))
+	for i, userLoginHistory := range s.UserLoginHistories {
+		userLoginHistories[i] = userLoginHistory.proto
+	}
+	return &pb.UserLoginHistories{
+		UserLoginHistories: userLoginHistories,
+		TotalRows:          s.TotalRows,
+	}
+}
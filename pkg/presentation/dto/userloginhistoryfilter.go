@@ -0,0 +1,63 @@
+package presentation
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserLoginHistoryFilter is a struct that represents the filter dto of the login history of a user.
+type UserLoginHistoryFilter struct {
+	proto *pb.UserLoginHistoryFilter
+}
+
+// NewUserLoginHistoryFilter creates a new *UserLoginHistoryFilter.
+func NewUserLoginHistoryFilter(pb *pb.UserLoginHistoryFilter) *UserLoginHistoryFilter {
+	return &UserLoginHistoryFilter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserLoginHistoryFilter.
+func (s *UserLoginHistoryFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"CreatedAtFrom: %v, "+
+		"CreatedAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.proto.UserId,
+		s.proto.CreatedAtFrom,
+		s.proto.CreatedAtTo,
+		s.proto.Limit,
+		s.proto.Offset)
+}
+
+// ToEntity returns a entity representation of the UserLoginHistoryFilter.
+func (s *UserLoginHistoryFilter) ToEntity() *me.UserLoginHistoryFilter {
+	createdAtFrom := time.Time{}
+	if s.proto.CreatedAtFrom != nil {
+		createdAtFrom = s.proto.CreatedAtFrom.AsTime()
+	}
+	createdAtTo := time.Time{}
+	if s.proto.CreatedAtTo != nil {
+		createdAtTo = s.proto.CreatedAtTo.AsTime()
+	}
+	limit := 0
+	if s.proto.Limit != nil {
+		limit = int(*s.proto.Limit)
+	}
+	offset := 0
+	if s.proto.Offset != nil {
+		offset = int(*s.proto.Offset)
+	}
+	return &me.UserLoginHistoryFilter{
+		UserId:        tuuid.FromString(s.proto.UserId),
+		CreatedAtFrom: createdAtFrom,
+		CreatedAtTo:   createdAtTo,
+		Limit:         limit,
+		Offset:        offset,
+	}
+}
@@ -0,0 +1,112 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserReadAccess is a struct that represents the dto of a read of the personal data of users.
+type UserReadAccess struct {
+	proto *pb.UserReadAccess
+}
+
+// NewUserReadAccess creates a new *UserReadAccess.
+func NewUserReadAccess(pb *pb.UserReadAccess) *UserReadAccess {
+	return &UserReadAccess{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserReadAccess.
+func (s *UserReadAccess) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"ActorId: %v, "+
+		"Operation: %v, "+
+		"Filter: %v, "+
+		"UserIds: %v, "+
+		"RequestId: %v, "+
+		"ReadAt: %v",
+		s.proto.Id,
+		s.proto.ActorId,
+		s.proto.Operation,
+		s.proto.Filter,
+		s.proto.UserIds,
+		s.proto.RequestId,
+		s.proto.ReadAt)
+}
+
+// NewUserReadAccessFromEntity creates a new *UserReadAccess from entity.
+func NewUserReadAccessFromEntity(entity me.UserReadAccess) *UserReadAccess {
+	userIds := make([]string, len(entity.UserIds))
+	for i, userId := range entity.UserIds {
+		userIds[i] = userId.String()
+	}
+	return &UserReadAccess{
+		&pb.UserReadAccess{
+			Id:        entity.Id.String(),
+			ActorId:   entity.ActorId.String(),
+			Operation: entity.Operation,
+			Filter:    entity.Filter,
+			UserIds:   userIds,
+			RequestId: entity.RequestId,
+			ReadAt:    timestamppb.New(entity.ReadAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserReadAccess.
+func (s *UserReadAccess) ToPb() *pb.UserReadAccess {
+	return s.proto
+}
+
+// ToEntity returns a entity representation of the UserReadAccess.
+func (s *UserReadAccess) ToEntity() *me.UserReadAccess {
+	userIds := make([]uuid.UUID, len(s.proto.UserIds))
+	for i, userId := range s.proto.UserIds {
+		userIds[i] = tuuid.FromString(userId)
+	}
+	return &me.UserReadAccess{
+		Id:        tuuid.FromString(s.proto.Id),
+		ActorId:   tuuid.FromString(s.proto.ActorId),
+		Operation: s.proto.Operation,
+		Filter:    s.proto.Filter,
+		UserIds:   userIds,
+		RequestId: s.proto.RequestId,
+		ReadAt:    s.proto.ReadAt.AsTime(),
+	}
+}
+
+type UserReadAccesses struct {
+	UserReadAccesses []*UserReadAccess `json:"user_read_accesses"`
+	TotalRows        int64             `json:"total_rows"`
+}
+
+// NewUserReadAccessFromEntities creates a new []*UserReadAccess from entities.
+func NewUserReadAccessFromEntities(entities me.UserReadAccesses) UserReadAccesses {
+	userReadAccesses := make([]*UserReadAccess, len(entities.UserReadAccesses))
+	for i, entity := range entities.UserReadAccesses {
+		userReadAccesses[i] = NewUserReadAccessFromEntity(entity)
+	}
+
+	return UserReadAccesses{
+		UserReadAccesses: userReadAccesses,
+		TotalRows:        entities.TotalRows,
+	}
+}
+
+// ToPbs returns a protobuf representation of the UserReadAccesses.
+func (s UserReadAccesses) ToPbs() *pb.UserReadAccesses {
+	userReadAccesses := make([]*pb.UserReadAccess, len(s.UserReadAccesses))
+	for i, userReadAccess := range s.UserReadAccesses {
+		userReadAccesses[i] = userReadAccess.proto
+	}
+	return &pb.UserReadAccesses{
+		UserReadAccesses: userReadAccesses,
+		TotalRows:        s.TotalRows,
+	}
+}
@@ -0,0 +1,66 @@
+package presentation
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserReadAccessFilter is a struct that represents the filter dto of the reads of the personal data of a user.
+type UserReadAccessFilter struct {
+	proto *pb.UserReadAccessFilter
+}
+
+// NewUserReadAccessFilter creates a new *UserReadAccessFilter.
+func NewUserReadAccessFilter(pb *pb.UserReadAccessFilter) *UserReadAccessFilter {
+	return &UserReadAccessFilter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserReadAccessFilter.
+func (s *UserReadAccessFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"ActorId: %v, "+
+		"ReadAtFrom: %v, "+
+		"ReadAtTo: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.proto.UserId,
+		s.proto.ActorId,
+		s.proto.ReadAtFrom,
+		s.proto.ReadAtTo,
+		s.proto.Limit,
+		s.proto.Offset)
+}
+
+// ToEntity returns a entity representation of the UserReadAccessFilter.
+func (s *UserReadAccessFilter) ToEntity() *me.UserReadAccessFilter {
+	readAtFrom := time.Time{}
+	if s.proto.ReadAtFrom != nil {
+		readAtFrom = s.proto.ReadAtFrom.AsTime()
+	}
+	readAtTo := time.Time{}
+	if s.proto.ReadAtTo != nil {
+		readAtTo = s.proto.ReadAtTo.AsTime()
+	}
+	limit := 0
+	if s.proto.Limit != nil {
+		limit = int(*s.proto.Limit)
+	}
+	offset := 0
+	if s.proto.Offset != nil {
+		offset = int(*s.proto.Offset)
+	}
+	return &me.UserReadAccessFilter{
+		UserId:     tuuid.FromString(s.proto.UserId),
+		ActorId:    tuuid.FromString(s.proto.ActorId),
+		ReadAtFrom: readAtFrom,
+		ReadAtTo:   readAtTo,
+		Limit:      limit,
+		Offset:     offset,
+	}
+}
@@ -0,0 +1,36 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserRegistration is a struct that represents the dto of a self-registration request.
+type UserRegistration struct {
+	proto *pb.UserRegistration
+}
+
+// NewUserRegistration creates a new *UserRegistration.
+func NewUserRegistration(pb *pb.UserRegistration) *UserRegistration {
+	return &UserRegistration{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserRegistration.
+func (s *UserRegistration) String() string {
+	return fmt.Sprintf("User: %v, "+
+		"Password: ***",
+		s.proto.User)
+}
+
+// ToEntity returns a entity representation of the UserRegistration.
+func (s *UserRegistration) ToEntity(clientIp string) *me.UserRegistration {
+	user := me.NewEmptyUser()
+	if s.proto.User != nil {
+		user = NewUser(s.proto.User).ToEntity()
+	}
+	return me.NewUserRegistration(*user, s.proto.Password, clientIp)
+}
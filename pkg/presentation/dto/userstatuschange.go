@@ -0,0 +1,43 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	mo "github.com/octoposprime/op-be-user/internal/domain/model/object"
+)
+
+// UserStatusChange is a struct that represents the dto of a requested user status transition.
+type UserStatusChange struct {
+	proto *pb.UserStatusChange
+}
+
+// NewUserStatusChange creates a new *UserStatusChange.
+func NewUserStatusChange(pb *pb.UserStatusChange) *UserStatusChange {
+	return &UserStatusChange{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserStatusChange.
+func (s *UserStatusChange) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"UserStatus: %v, "+
+		"Reason: %v",
+		s.proto.UserId,
+		s.proto.UserStatus,
+		s.proto.Reason)
+}
+
+// ToEntity returns a entity representation of the UserStatusChange.
+func (s *UserStatusChange) ToEntity() *me.UserStatusChange {
+	return &me.UserStatusChange{
+		UserId:     tuuid.FromString(s.proto.UserId),
+		UserStatus: mo.UserStatus(s.proto.UserStatus),
+		Reason:     s.proto.Reason,
+
+		SuspendedUntil: fromTimestamp(s.proto.SuspendedUntil),
+	}
+}
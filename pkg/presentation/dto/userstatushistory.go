@@ -0,0 +1,89 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserStatusHistory is a struct that represents the dto of an applied user status transition.
+type UserStatusHistory struct {
+	proto *pb.UserStatusHistory
+}
+
+// NewUserStatusHistory creates a new *UserStatusHistory.
+func NewUserStatusHistory(pb *pb.UserStatusHistory) *UserStatusHistory {
+	return &UserStatusHistory{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserStatusHistory.
+func (s *UserStatusHistory) String() string {
+	return fmt.Sprintf("Id: %v, "+
+		"UserId: %v, "+
+		"FromStatus: %v, "+
+		"ToStatus: %v, "+
+		"Reason: %v, "+
+		"ActorId: %v",
+		s.proto.Id,
+		s.proto.UserId,
+		s.proto.FromStatus,
+		s.proto.ToStatus,
+		s.proto.Reason,
+		s.proto.ActorId)
+}
+
+// NewUserStatusHistoryFromEntity creates a new *UserStatusHistory from entity.
+func NewUserStatusHistoryFromEntity(entity me.UserStatusHistory) *UserStatusHistory {
+	return &UserStatusHistory{
+		&pb.UserStatusHistory{
+			Id:         entity.Id.String(),
+			UserId:     entity.UserId.String(),
+			FromStatus: pb.UserStatus(entity.FromStatus),
+			ToStatus:   pb.UserStatus(entity.ToStatus),
+			Reason:     entity.Reason,
+			ActorId:    entity.ActorId.String(),
+
+			// Only for view
+			CreatedAt: timestamppb.New(entity.CreatedAt),
+		},
+	}
+}
+
+// ToPb returns a protobuf representation of the UserStatusHistory.
+func (s *UserStatusHistory) ToPb() *pb.UserStatusHistory {
+	return s.proto
+}
+
+type UserStatusHistories struct {
+	UserStatusHistories []*UserStatusHistory `json:"user_status_histories"`
+	TotalRows           int64                `json:"total_rows"`
+}
+
+// NewUserStatusHistoryFromEntities creates a new []*UserStatusHistory from entities.
+func NewUserStatusHistoryFromEntities(entities me.UserStatusHistories) UserStatusHistories {
+	userStatusHistories := make([]*UserStatusHistory, len(entities.UserStatusHistories))
+	for i, entity := range entities.UserStatusHistories {
+		userStatusHistories[i] = NewUserStatusHistoryFromEntity(entity)
+	}
+
+	return UserStatusHistories{
+		UserStatusHistories: userStatusHistories,
+		TotalRows:           entities.TotalRows,
+	}
+}
+
+// ToPbs returns a protobuf representation of the UserStatusHistories.
+func (s UserStatusHistories) ToPbs() *pb.UserStatusHistories {
+	userStatusHistories := make([]*pb.UserStatusHistory, len(s.UserStatusHistories))
+	for i, userStatusHistory := range s.UserStatusHistories {
+		userStatusHistories[i] = userStatusHistory.proto
+	}
+	return &pb.UserStatusHistories{
+		UserStatusHistories: userStatusHistories,
+		TotalRows:           s.TotalRows,
+	}
+}
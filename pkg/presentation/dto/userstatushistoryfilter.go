@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"fmt"
+
+	pb "github.com/octoposprime/op-be-shared/pkg/proto/pb/user"
+	tuuid "github.com/octoposprime/op-be-shared/tool/uuid"
+	me "github.com/octoposprime/op-be-user/internal/domain/model/entity"
+)
+
+// UserStatusHistoryFilter is a struct that represents the filter dto of the status history of a user.
+type UserStatusHistoryFilter struct {
+	proto *pb.UserStatusHistoryFilter
+}
+
+// NewUserStatusHistoryFilter creates a new *UserStatusHistoryFilter.
+func NewUserStatusHistoryFilter(pb *pb.UserStatusHistoryFilter) *UserStatusHistoryFilter {
+	return &UserStatusHistoryFilter{
+		proto: pb,
+	}
+}
+
+// String returns a string representation of the UserStatusHistoryFilter.
+func (s *UserStatusHistoryFilter) String() string {
+	return fmt.Sprintf("UserId: %v, "+
+		"Limit: %v, "+
+		"Offset: %v",
+		s.proto.UserId,
+		s.proto.Limit,
+		s.proto.Offset)
+}
+
+// ToEntity returns a entity representation of the UserStatusHistoryFilter.
+func (s *UserStatusHistoryFilter) ToEntity() *me.UserStatusHistoryFilter {
+	limit := 0
+	if s.proto.Limit != nil {
+		limit = int(*s.proto.Limit)
+	}
+	offset := 0
+	if s.proto.Offset != nil {
+		offset = int(*s.proto.Offset)
+	}
+	return &me.UserStatusHistoryFilter{
+		UserId: tuuid.FromString(s.proto.UserId),
+		Limit:  limit,
+		Offset: offset,
+	}
+}
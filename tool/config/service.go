@@ -0,0 +1,167 @@
+package tconfig
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ServiceConfig holds the user service specific sections of the internal config.
+type ServiceConfig struct {
+	Registration struct {
+		Enabled               bool     `yaml:"enabled"`                 // Enabled switches the public self-registration on.
+		DefaultRole           string   `yaml:"default_role"`            // DefaultRole is the role given to the self-registered users.
+		DefaultUserType       int      `yaml:"default_user_type"`       // DefaultUserType is the type given to the self-registered users.
+		AllowedEmailDomains   []string `yaml:"allowed_email_domains"`   // AllowedEmailDomains restricts the registration to these domains if it is not empty.
+		DeniedEmailDomains    []string `yaml:"denied_email_domains"`    // DeniedEmailDomains is the list of rejected domains.
+		DisposableDomainsPath string   `yaml:"disposable_domains_path"` // DisposableDomainsPath is the path of the disposable domain blocklist file.
+		RateLimitPerIp        int64    `yaml:"rate_limit_per_ip"`       // RateLimitPerIp is the maximum number of registrations of an ip in the window.
+		RateLimitWindow       int      `yaml:"rate_limit_window"`       // RateLimitWindow is the rate limit window in seconds.
+		TrustedProxies        []string `yaml:"trusted_proxies"`         // TrustedProxies are the ips or cidrs of the gateways whose forwarded client ip is honoured.
+
+		DisposableDomains []string `yaml:"-"` // DisposableDomains is the content of the blocklist file.
+	} `yaml:"registration"`
+	Lifecycle struct {
+		JobInterval int `yaml:"job_interval"` // JobInterval is the period of the suspension and expiry job in seconds.
+		BatchSize   int `yaml:"batch_size"`   // BatchSize is the number of the users read at once by the job.
+		LockTtl     int `yaml:"lock_ttl"`     // LockTtl is the time in seconds a replica holds the job, it must be longer than a run.
+	} `yaml:"lifecycle"`
+	Activity struct {
+		FlushInterval int `yaml:"flush_interval"` // FlushInterval is the period of writing the buffered login and activity times to the db in seconds.
+		LockTtl       int `yaml:"lock_ttl"`       // LockTtl is the time in seconds a replica holds the flush, it must be longer than a flush.
+	} `yaml:"activity"`
+	Dormancy struct {
+		InactiveDays   int `yaml:"inactive_days"`   // InactiveDays is the number of inactive days after which the user is warned, 0 disables the policy.
+		DeactivateDays int `yaml:"deactivate_days"` // DeactivateDays is the number of inactive days after which the warned user is deactivated.
+		JobInterval    int `yaml:"job_interval"`    // JobInterval is the period of the dormancy job in seconds.
+		BatchSize      int `yaml:"batch_size"`      // BatchSize is the maximum number of the users read at once by the dormancy job.
+		LockTtl        int `yaml:"lock_ttl"`        // LockTtl is the time in seconds a replica holds the dormancy job, it must be longer than a run.
+	} `yaml:"dormancy"`
+	Outbox struct {
+		RelayInterval int `yaml:"relay_interval"` // RelayInterval is the period of delivering the outbox events to the event bus in seconds.
+		BatchSize     int `yaml:"batch_size"`     // BatchSize is the maximum number of the events delivered in one relay run.
+		MaxBackoff    int `yaml:"max_backoff"`    // MaxBackoff is the maximum delay between the delivery attempts of an event in seconds.
+		LockTtl       int `yaml:"lock_ttl"`       // LockTtl is the time in seconds a replica holds the relay, it must be longer than a run.
+	} `yaml:"outbox"`
+	EBus struct {
+		Backend      string `yaml:"backend"`        // Backend is the backend of the event bus, "redis" or "memory".
+		Group        string `yaml:"group"`          // Group is the consumer group of the service on the event bus streams.
+		Consumer     string `yaml:"consumer"`       // Consumer is the consumer name of the replica, the host name is used if it is empty.
+		BatchSize    int64  `yaml:"batch_size"`     // BatchSize is the maximum number of the messages read at once.
+		Block        int    `yaml:"block"`          // Block is the maximum wait time of a read in seconds.
+		ClaimMinIdle int    `yaml:"claim_min_idle"` // ClaimMinIdle is the idle time in seconds after which an unacknowledged message is reclaimed.
+		BackoffMin   int    `yaml:"backoff_min"`    // BackoffMin is the first delay after a failure in seconds.
+		BackoffMax   int    `yaml:"backoff_max"`    // BackoffMax is the maximum delay after the consecutive failures in seconds.
+		MaxAttempts  int64  `yaml:"max_attempts"`   // MaxAttempts is the number of the deliveries after which a failing message is dead-lettered.
+		DedupTtl     int    `yaml:"dedup_ttl"`      // DedupTtl is the time in seconds the processed event ids are remembered.
+		Workers      int    `yaml:"workers"`        // Workers is the number of the messages handled at the same time.
+		QueueSize    int    `yaml:"queue_size"`     // QueueSize is the number of the messages waiting for a worker after which the reading is paused.
+		ShutdownWait int    `yaml:"shutdown_wait"`  // ShutdownWait is the maximum time in seconds to wait for the messages in progress on shutdown.
+		ReplyMaxLen  int64  `yaml:"reply_max_len"`  // ReplyMaxLen is the approximate number of the command results kept on a reply stream.
+
+		QueryTimeout  int `yaml:"query_timeout"`   // QueryTimeout is the time in seconds a query without an expiry time is answered in.
+		QueryReplyTtl int `yaml:"query_reply_ttl"` // QueryReplyTtl is the time in seconds a reply stream of a query is kept after the last reply.
+		QueryMaxLimit int `yaml:"query_max_limit"` // QueryMaxLimit is the maximum number of the users returned by a query, it is also the limit of a query without one.
+	} `yaml:"ebus"`
+	Idempotency struct {
+		Ttl     int `yaml:"ttl"`      // Ttl is the time in seconds the outcome of an idempotent request is kept.
+		LockTtl int `yaml:"lock_ttl"` // LockTtl is the time in seconds a request in progress holds its idempotency key.
+	} `yaml:"idempotency"`
+	ReadAudit struct {
+		Enabled       bool `yaml:"enabled"`        // Enabled switches the recording of the reads of the personal data of the users on.
+		FlushInterval int  `yaml:"flush_interval"` // FlushInterval is the period of writing the buffered reads to the db in seconds.
+		LockTtl       int  `yaml:"lock_ttl"`       // LockTtl is the time in seconds a replica holds the flush, it must be longer than a flush.
+	} `yaml:"read_audit"`
+	Retention struct {
+		JobInterval int  `yaml:"job_interval"` // JobInterval is the period of the retention job in seconds.
+		BatchSize   int  `yaml:"batch_size"`   // BatchSize is the maximum number of the records purged at once.
+		DryRun      bool `yaml:"dry_run"`      // DryRun switches the job to only reporting the records to purge.
+		LockTtl     int  `yaml:"lock_ttl"`     // LockTtl is the time in seconds a replica holds the retention job, it must be longer than a run.
+
+		DeletedUserDays      int `yaml:"deleted_user_days"`       // DeletedUserDays is the number of days the soft-deleted users are kept before the erasure, 0 keeps them forever.
+		InactivePasswordDays int `yaml:"inactive_password_days"`  // InactivePasswordDays is the number of days the inactive passwords are kept, 0 keeps them forever.
+		LoginHistoryDays     int `yaml:"login_history_days"`      // LoginHistoryDays is the number of days the authentication attempts are kept, 0 keeps them forever.
+		SentOutboxEventHours int `yaml:"sent_outbox_event_hours"` // SentOutboxEventHours is the number of hours the delivered events are kept, 0 keeps them forever.
+	} `yaml:"retention"`
+	Batch struct {
+		ChunkSize int `yaml:"chunk_size"` // ChunkSize is the number of the users read at once by the batch commands and the imports.
+		MaxSize   int `yaml:"max_size"`   // MaxSize is the maximum number of the users of a batch command, 0 is unlimited.
+	} `yaml:"batch"`
+	Notification struct {
+		Host string `yaml:"host"` // Host is the host of the notification micro service.
+		Port string `yaml:"port"` // Port is the port of the notification micro service.
+	} `yaml:"notification"`
+}
+
+var ServiceConfigPath string = "config/internal.yml"
+var ServiceConfigTestPath string = "config/internal_test.yml"
+var ServiceConfigLocalPath string = "config/internal_local.yml"
+
+var ServiceConfigInstance *ServiceConfig
+
+func GetServiceConfigInstance() *ServiceConfig {
+	if ServiceConfigInstance == nil {
+		ServiceConfigInstance = &ServiceConfig{}
+		ServiceConfigInstance.ReadConfig()
+	}
+	return ServiceConfigInstance
+}
+
+func (c *ServiceConfig) ReadConfig() {
+	configPath := ServiceConfigPath
+	if os.Getenv("LOCAL") != "" {
+		if os.Getenv("LOCAL") == "true" {
+			configPath = ServiceConfigLocalPath
+		}
+	} else {
+		if os.Getenv("TEST") != "" {
+			if os.Getenv("TEST") == "true" {
+				configPath = ServiceConfigTestPath
+			}
+		}
+	}
+
+	f, err := os.Open(configPath)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	byteValue, _ := io.ReadAll(f)
+
+	err = yaml.Unmarshal(byteValue, c)
+	if err != nil {
+		panic(err)
+	}
+
+	if c.EBus.Consumer == "" {
+		c.EBus.Consumer, _ = os.Hostname()
+	}
+
+	if c.Registration.DisposableDomainsPath != "" {
+		c.Registration.DisposableDomains = readLines(c.Registration.DisposableDomainsPath)
+	}
+}
+
+// readLines returns the non-empty and non-comment lines of the given file.
+func readLines(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.ToLower(line))
+	}
+	return lines
+}